@@ -0,0 +1,200 @@
+package dbr
+
+// AggregateFilter builds the aggregate fn over expr restricted to rows matching cond,
+// e.g. AggregateFilter("COUNT", "*", Eq("active", true)).
+// It renders `fn(expr) FILTER (WHERE cond)` in dialects supporting FILTER,
+// and `fn(CASE WHEN cond THEN expr END)` otherwise, which is equivalent
+// because aggregates skip NULL. `*` is counted as 1 in the CASE form.
+func AggregateFilter(fn, expr string, cond Builder) Builder {
+	return BuildFunc(func(d Dialect, buf Buffer) error {
+		buf.WriteString(fn)
+		buf.WriteString("(")
+		if f, ok := baseDialect(d).(AggregateFilterDialect); ok && f.SupportsAggregateFilter() {
+			buf.WriteString(expr)
+			buf.WriteString(") FILTER (WHERE ")
+			err := cond.Build(d, buf)
+			if err != nil {
+				return err
+			}
+			buf.WriteString(")")
+			return nil
+		}
+
+		buf.WriteString("CASE WHEN ")
+		err := cond.Build(d, buf)
+		if err != nil {
+			return err
+		}
+		buf.WriteString(" THEN ")
+		if expr == "*" {
+			buf.WriteString("1")
+		} else {
+			buf.WriteString(expr)
+		}
+		buf.WriteString(" END)")
+		return nil
+	})
+}
+
+// RunningTotal builds the running total of value column of table ordered by order column,
+// restarting for each combination of partition columns. table is the table of the query, it must not be aliased.
+// It renders `SUM(value) OVER (PARTITION BY ... ORDER BY order)` in dialects supporting window functions,
+// and a correlated subquery summing the rows of table up to the current one otherwise
+// (which is quadratic in the number of rows, so keep partitions small).
+// Rows with equal order get the same total in both forms.
+func RunningTotal(table, value, order string, partition ...string) interface {
+	Builder
+	As(string) Builder
+} {
+	return aggregate(func(d Dialect, buf Buffer) error {
+		column := func(table, column string) string {
+			return d.QuoteIdent(table + "." + column)
+		}
+		if w, ok := baseDialect(d).(WindowDialect); ok && w.SupportsWindow() {
+			buf.WriteString("SUM(")
+			buf.WriteString(column(table, value))
+			buf.WriteString(") OVER (")
+			if len(partition) > 0 {
+				buf.WriteString("PARTITION BY ")
+				for i, p := range partition {
+					if i > 0 {
+						buf.WriteString(", ")
+					}
+					buf.WriteString(column(table, p))
+				}
+				buf.WriteString(" ")
+			}
+			buf.WriteString("ORDER BY ")
+			buf.WriteString(column(table, order))
+			buf.WriteString(")")
+			return nil
+		}
+
+		const inner = "dbr_running_total"
+		buf.WriteString("(SELECT SUM(")
+		buf.WriteString(column(inner, value))
+		buf.WriteString(") FROM ")
+		buf.WriteString(d.QuoteIdent(table))
+		buf.WriteString(" AS ")
+		buf.WriteString(d.QuoteIdent(inner))
+		buf.WriteString(" WHERE ")
+		for _, p := range partition {
+			buf.WriteString(column(inner, p))
+			buf.WriteString(" = ")
+			buf.WriteString(column(table, p))
+			buf.WriteString(" AND ")
+		}
+		buf.WriteString(column(inner, order))
+		buf.WriteString(" <= ")
+		buf.WriteString(column(table, order))
+		buf.WriteString(")")
+		return nil
+	})
+}
+
+// aggregate is an aggregate function which can be aliased in the select list
+type aggregate BuildFunc
+
+func (a aggregate) Build(d Dialect, buf Buffer) error {
+	return a(d, buf)
+}
+
+// As creates an alias for the aggregate, e.g. `json_agg(...) AS "items"`
+func (a aggregate) As(alias string) Builder {
+	return as(a, alias)
+}
+
+// buildExpr renders expr, a raw SQL string or a Builder, for use as a function argument
+func buildExpr(d Dialect, expr interface{}) (string, []interface{}, error) {
+	switch expr := expr.(type) {
+	case string:
+		return expr, nil, nil
+	case Builder:
+		buf := NewBuffer()
+		err := expr.Build(d, buf)
+		if err != nil {
+			return "", nil, err
+		}
+		return buf.String(), buf.Value(), nil
+	}
+	return "", nil, ErrNotSupported
+}
+
+// JSONAgg aggregates expr, a raw SQL string or a Builder, into a JSON array,
+// e.g. `json_agg(expr)` in PostgreSQL and `JSON_ARRAYAGG(expr)` in MySQL.
+// Use JSONObject to aggregate columns of rows.
+func JSONAgg(expr interface{}) interface {
+	Builder
+	As(string) Builder
+} {
+	return aggregate(func(d Dialect, buf Buffer) error {
+		j, ok := baseDialect(d).(JSONDialect)
+		if !ok {
+			return ErrNotSupported
+		}
+		s, value, err := buildExpr(d, expr)
+		if err != nil {
+			return err
+		}
+		query := j.JSONAgg(s)
+		if query == "" {
+			return ErrNotSupported
+		}
+		buf.WriteString(query)
+		buf.WriteValue(value...)
+		return nil
+	})
+}
+
+// JSONObjectAgg aggregates key and value pairs into a JSON object,
+// e.g. `json_object_agg(key, value)` in PostgreSQL and `JSON_OBJECTAGG(key, value)` in MySQL.
+// key and value are raw SQL strings or Builders.
+func JSONObjectAgg(key, value interface{}) interface {
+	Builder
+	As(string) Builder
+} {
+	return aggregate(func(d Dialect, buf Buffer) error {
+		j, ok := baseDialect(d).(JSONDialect)
+		if !ok {
+			return ErrNotSupported
+		}
+		k, kValue, err := buildExpr(d, key)
+		if err != nil {
+			return err
+		}
+		v, vValue, err := buildExpr(d, value)
+		if err != nil {
+			return err
+		}
+		query := j.JSONObjectAgg(k, v)
+		if query == "" {
+			return ErrNotSupported
+		}
+		buf.WriteString(query)
+		buf.WriteValue(kValue...)
+		buf.WriteValue(vValue...)
+		return nil
+	})
+}
+
+// JSONObject builds a JSON object of columns keyed by their names,
+// e.g. `json_build_object('id', "id", 'name', "name")` in PostgreSQL,
+// to aggregate rows of a subquery with JSONAgg.
+func JSONObject(column ...string) Builder {
+	return BuildFunc(func(d Dialect, buf Buffer) error {
+		j, ok := baseDialect(d).(JSONDialect)
+		if !ok {
+			return ErrNotSupported
+		}
+		pair := make([]string, 0, 2*len(column))
+		for _, col := range column {
+			pair = append(pair, d.EncodeString(col), d.QuoteIdent(col))
+		}
+		query := j.JSONObject(pair)
+		if query == "" {
+			return ErrNotSupported
+		}
+		buf.WriteString(query)
+		return nil
+	})
+}
@@ -0,0 +1,187 @@
+package dbr
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/lianchengwu/dbr/dialect"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAggregateFilter(t *testing.T) {
+	builder := Select(
+		"team",
+		AggregateFilter("COUNT", "*", Eq("active", true)),
+		AggregateFilter("SUM", "amount", Gt("amount", 10)),
+	).From("users").Where(Eq("org", 1)).GroupBy("team")
+
+	for _, test := range []struct {
+		d     Dialect
+		query string
+	}{
+		{
+			d:     dialect.PostgreSQL,
+			query: `SELECT team, COUNT(*) FILTER (WHERE "active" = TRUE), SUM(amount) FILTER (WHERE "amount" > 10) FROM users WHERE ("org" = 1) GROUP BY team`,
+		},
+		{
+			d:     dialect.SQLite3,
+			query: `SELECT team, COUNT(CASE WHEN "active" = 1 THEN 1 END), SUM(CASE WHEN "amount" > 10 THEN amount END) FROM users WHERE ("org" = 1) GROUP BY team`,
+		},
+		{
+			d:     dialect.SQLite3Version(3, 30),
+			query: `SELECT team, COUNT(*) FILTER (WHERE "active" = 1), SUM(amount) FILTER (WHERE "amount" > 10) FROM users WHERE ("org" = 1) GROUP BY team`,
+		},
+		{
+			d:     dialect.MySQL,
+			query: "SELECT team, COUNT(CASE WHEN `active` = 1 THEN 1 END), SUM(CASE WHEN `amount` > 10 THEN amount END) FROM users WHERE (`org` = 1) GROUP BY team",
+		},
+	} {
+		buf := NewBuffer()
+		err := builder.Build(test.d, buf)
+		assert.NoError(t, err)
+		query, err := InterpolateForDialect(buf.String(), buf.Value(), test.d)
+		assert.NoError(t, err)
+		assert.Equal(t, test.query, query)
+	}
+
+	for _, sess := range testSession {
+		prefix := fmt.Sprintf("filter_%d_", nextID())
+		_, err := sess.InsertInto("dbr_keys").Columns("key_value", "val_value").
+			Values(prefix+"1", "a").Values(prefix+"2", "b").Values(prefix+"3", "a").Exec()
+		assert.NoError(t, err)
+
+		var count int64
+		err = sess.Select().Columns(AggregateFilter("COUNT", "*", Eq("val_value", "a"))).From("dbr_keys").
+			Where(Like("key_value", prefix+"%")).LoadValue(&count)
+		assert.NoError(t, err)
+		assert.EqualValues(t, 2, count)
+	}
+}
+
+func TestJSONAggStmt(t *testing.T) {
+	builder := Select(
+		"team",
+		JSONAgg(JSONObject("id", "name")).As("members"),
+		JSONObjectAgg("name", Expr("score * ?", 2)).As("scores"),
+	).From(Select("*").From("users").Where(Eq("active", true)).As("t")).GroupBy("team")
+
+	for _, test := range []struct {
+		d     Dialect
+		query string
+	}{
+		{
+			d: dialect.PostgreSQL,
+			query: `SELECT team, json_agg(json_build_object('id', "id", 'name', "name")) AS "members", ` +
+				`json_object_agg(name, score * 2) AS "scores" ` +
+				`FROM (SELECT * FROM users WHERE ("active" = TRUE)) AS "t" GROUP BY team`,
+		},
+		{
+			d: dialect.MySQL,
+			query: "SELECT team, JSON_ARRAYAGG(JSON_OBJECT('id', `id`, 'name', `name`)) AS `members`, " +
+				"JSON_OBJECTAGG(name, score * 2) AS `scores` " +
+				"FROM (SELECT * FROM users WHERE (`active` = 1)) AS `t` GROUP BY team",
+		},
+	} {
+		buf := NewBuffer()
+		err := builder.Build(test.d, buf)
+		assert.NoError(t, err)
+		query, err := InterpolateForDialect(buf.String(), buf.Value(), test.d)
+		assert.NoError(t, err)
+		assert.Equal(t, test.query, query)
+	}
+
+	_, err := InterpolateForDialect("?", []interface{}{JSONAgg("a")}, dialect.ClickHouse)
+	assert.Equal(t, ErrNotSupported, err)
+}
+
+func TestJSONAgg(t *testing.T) {
+	for _, sess := range testSession {
+		if sess.Dialect == dialect.ClickHouse || sess.Dialect == dialect.SQLite3 {
+			// clickhouse does not support json aggregation,
+			// sqlite3 returns json as string and needs the sqlite_json build tag
+			continue
+		}
+		keys := []string{fmt.Sprintf("json_%d_a", nextID()), fmt.Sprintf("json_%d_b", nextID())}
+		_, err := sess.InsertInto("dbr_keys").Columns("key_value", "val_value").
+			Values(keys[0], "1").
+			Values(keys[1], "2").
+			Exec()
+		assert.NoError(t, err)
+
+		var result struct {
+			Rows json.RawMessage
+			Vals json.RawMessage
+		}
+		err = sess.Select("*").From(Select(
+			JSONAgg(JSONObject("key_value", "val_value")).As("rows"),
+			JSONObjectAgg("key_value", "val_value").As("vals"),
+		).From("dbr_keys").Where(Eq("key_value", keys)).As("t")).LoadStruct(&result)
+		assert.NoError(t, err)
+
+		var rows []map[string]string
+		assert.NoError(t, json.Unmarshal(result.Rows, &rows))
+		assert.ElementsMatch(t, []map[string]string{
+			{"key_value": keys[0], "val_value": "1"},
+			{"key_value": keys[1], "val_value": "2"},
+		}, rows)
+
+		var vals map[string]string
+		assert.NoError(t, json.Unmarshal(result.Vals, &vals))
+		assert.Equal(t, map[string]string{keys[0]: "1", keys[1]: "2"}, vals)
+	}
+}
+
+// noWindow is a dialect without window functions
+type noWindow struct {
+	Dialect
+}
+
+func (noWindow) SupportsWindow() bool {
+	return false
+}
+
+func TestRunningTotal(t *testing.T) {
+	builder := Select("id", RunningTotal("payments", "amount", "id", "account_id").As("total")).From("payments")
+	for _, test := range []struct {
+		d     Dialect
+		query string
+	}{
+		{
+			d:     dialect.PostgreSQL,
+			query: `SELECT id, SUM("payments"."amount") OVER (PARTITION BY "payments"."account_id" ORDER BY "payments"."id") AS "total" FROM payments`,
+		},
+		{
+			d: dialect.MySQL,
+			query: "SELECT id, (SELECT SUM(`dbr_running_total`.`amount`) FROM `payments` AS `dbr_running_total` " +
+				"WHERE `dbr_running_total`.`account_id` = `payments`.`account_id` AND `dbr_running_total`.`id` <= `payments`.`id`) AS `total` FROM payments",
+		},
+	} {
+		buf := NewBuffer()
+		err := builder.Build(test.d, buf)
+		assert.NoError(t, err)
+		query, err := InterpolateForDialect(buf.String(), buf.Value(), test.d)
+		assert.NoError(t, err)
+		assert.Equal(t, test.query, query)
+	}
+
+	// both forms compute the same totals
+	tx, err := sqlite3Session.Begin()
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer tx.RollbackUnlessCommitted()
+	_, err = tx.Exec("CREATE TEMP TABLE dbr_payments (id integer PRIMARY KEY, account_id integer, amount integer)")
+	assert.NoError(t, err)
+	_, err = tx.InsertInto("dbr_payments").Columns("id", "account_id", "amount").
+		Values(1, 1, 10).Values(2, 2, 5).Values(3, 1, 20).Values(4, 2, 1).Values(5, 1, 3).Exec()
+	assert.NoError(t, err)
+	for _, d := range []Dialect{dialect.SQLite3, noWindow{dialect.SQLite3}} {
+		tx.Dialect = d
+		var total []int
+		_, err = tx.Select().Columns(RunningTotal("dbr_payments", "amount", "id", "account_id")).
+			From("dbr_payments").OrderAsc("id").Load(&total)
+		assert.NoError(t, err)
+		assert.Equal(t, []int{10, 5, 30, 6, 33}, total)
+	}
+}
@@ -1,6 +1,9 @@
 package dbr
 
-import "bytes"
+import (
+	"bytes"
+	"strings"
+)
 
 // Buffer is an interface used by Builder to store intermediate results
 type Buffer interface {
@@ -29,3 +32,23 @@ func (b *buffer) WriteValue(v ...interface{}) error {
 func (b *buffer) Value() []interface{} {
 	return b.v
 }
+
+// CountPlaceholders returns the number of placeholders written to buf,
+// it must be equal to len(buf.Value()) for a valid query
+func CountPlaceholders(buf Buffer) int {
+	return strings.Count(buf.String(), placeholder)
+}
+
+// CheckPlaceholders returns *PlaceholderCountError if the number of placeholders in buf
+// does not match the number of values
+func CheckPlaceholders(buf Buffer) error {
+	return checkPlaceholders(buf.String(), buf.Value())
+}
+
+func checkPlaceholders(query string, value []interface{}) error {
+	n := strings.Count(query, placeholder)
+	if n != len(value) {
+		return &PlaceholderCountError{Query: query, Placeholders: n, Values: len(value)}
+	}
+	return nil
+}
@@ -1 +1,33 @@
 package dbr
+
+import (
+	"testing"
+
+	"github.com/lianchengwu/dbr/dialect"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckPlaceholders(t *testing.T) {
+	buf := NewBuffer()
+	err := Select("a").From("table").Where(Eq("b", 1)).Build(dialect.MySQL, buf)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, CountPlaceholders(buf))
+	assert.NoError(t, CheckPlaceholders(buf))
+
+	buf.WriteString(" AND c = ?")
+	assert.Equal(t, 2, CountPlaceholders(buf))
+	assert.EqualError(t, CheckPlaceholders(buf), "dbr: wrong placeholder count: 2 placeholders and 1 values in \"SELECT a FROM table WHERE (`b` = ?) AND c = ?\"")
+
+	err = Select("a").From("table").Where("b = ? AND c = ?", 1).Build(dialect.MySQL, NewBuffer())
+	if assert.IsType(t, &PlaceholderCountError{}, err) {
+		e := err.(*PlaceholderCountError)
+		assert.Equal(t, "b = ? AND c = ?", e.Query)
+		assert.Equal(t, 2, e.Placeholders)
+		assert.Equal(t, 1, e.Values)
+		assert.Equal(t, ErrPlaceholderCount, e.Unwrap())
+	}
+
+	sess, _ := newFakeSession(dialect.MySQL)
+	_, err = sess.Update("table").Set("a", Expr("? + ?", 1)).Exec()
+	assert.EqualError(t, err, `dbr: wrong placeholder count: 2 placeholders and 1 values in "? + ?"`)
+}
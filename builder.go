@@ -13,3 +13,15 @@ type BuildFunc func(Dialect, Buffer) error
 func (b BuildFunc) Build(d Dialect, buf Buffer) error {
 	return b(d, buf)
 }
+
+// buildValue writes placeholder for value, a Builder (e.g. Expr) is rendered in place with its values.
+// Subqueries are kept as placeholder because they are parenthesized on interpolation.
+func buildValue(d Dialect, buf Buffer, value interface{}) error {
+	switch value := value.(type) {
+	case SelectStmt, *union:
+	case Builder:
+		return value.Build(d, buf)
+	}
+	buf.WriteString(placeholder)
+	return buf.WriteValue(value)
+}
@@ -0,0 +1,105 @@
+package dbr
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CacheKey returns a stable key for builder in dialect, e.g. for query result caching.
+// The key consists of the built query and its values encoded with their types, so keys are equal
+// only for the same query with the same values. Values must be nil, driver.Valuer, time.Time, []byte,
+// strings, numbers, booleans, or pointers and slices of them, other values return ErrInvalidCacheValue.
+func CacheKey(d Dialect, builder Builder) (string, error) {
+	pbuf := NewBuffer()
+	err := builder.Build(d, pbuf)
+	if err != nil {
+		return "", err
+	}
+	i := interpolator{
+		Buffer:  NewBuffer(),
+		Dialect: d,
+		Bind:    true,
+	}
+	err = i.interpolate(pbuf.String(), pbuf.Value())
+	if err != nil {
+		return "", err
+	}
+
+	query := i.String()
+	key := new(strings.Builder)
+	key.WriteString(strconv.Itoa(len(query)))
+	key.WriteString(":")
+	key.WriteString(query)
+	for _, v := range i.Value() {
+		s, err := encodeCacheValue(v)
+		if err != nil {
+			return "", err
+		}
+		key.WriteString(";")
+		key.WriteString(s)
+	}
+	return key.String(), nil
+}
+
+// encodeCacheValue encodes value unambiguously with its type
+func encodeCacheValue(value interface{}) (string, error) {
+	if value == nil {
+		return "nil", nil
+	}
+	t := fmt.Sprintf("%T", value)
+	if valuer, ok := value.(driver.Valuer); ok {
+		v, err := valuer.Value()
+		if err != nil {
+			return "", err
+		}
+		s, err := encodeCacheValue(v)
+		if err != nil {
+			return "", err
+		}
+		return t + "(" + s + ")", nil
+	}
+
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return t + "(nil)", nil
+		}
+		s, err := encodeCacheValue(v.Elem().Interface())
+		if err != nil {
+			return "", err
+		}
+		return t + "(" + s + ")", nil
+	case reflect.String:
+		return t + "(" + strconv.Quote(v.String()) + ")", nil
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return fmt.Sprintf("%s(%v)", t, value), nil
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8 {
+			return fmt.Sprintf("%s(%x)", t, v.Bytes()), nil
+		}
+		// elements are encoded one by one, %#v would print addresses of pointers
+		elem := make([]string, v.Len())
+		for n := range elem {
+			s, err := encodeCacheValue(v.Index(n).Interface())
+			if err != nil {
+				return "", err
+			}
+			elem[n] = s
+		}
+		return t + "(" + strings.Join(elem, ",") + ")", nil
+	case reflect.Struct:
+		if tm, ok := value.(time.Time); ok {
+			// %v includes monotonic clock reading
+			return t + "(" + tm.Format(time.RFC3339Nano) + ")", nil
+		}
+	}
+	return "", ErrInvalidCacheValue
+}
@@ -0,0 +1,54 @@
+package dbr
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lianchengwu/dbr/dialect"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCacheKey(t *testing.T) {
+	query := func(v ...interface{}) Builder {
+		return Select("a").From("table").
+			Where(Eq("b", v[0])).
+			Where(Eq("c", v[1])).
+			Where("d IN ?", Select("d").From("table2").Where(Eq("e", v[2])))
+	}
+	ts := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	key := func(b Builder) string {
+		s, err := CacheKey(dialect.PostgreSQL, b)
+		assert.NoError(t, err)
+		return s
+	}
+
+	k := key(query(1, "x", ts))
+	assert.Equal(t, k, key(query(1, "x", ts)))
+	// monotonic clock reading is ignored
+	now := time.Now()
+	assert.Equal(t, key(query(1, "x", now)), key(query(1, "x", now.Round(0))))
+	assert.NotEqual(t, k, key(query(2, "x", ts)))
+	assert.NotEqual(t, k, key(query(1, "y", ts)))
+	assert.NotEqual(t, k, key(query(1, "x", ts.Add(time.Nanosecond))))
+	// same textual value, different type
+	assert.NotEqual(t, key(query(1, "x", ts)), key(query("1", "x", ts)))
+	// ambiguous concatenation
+	assert.NotEqual(t, key(query("a;b", "c", ts)), key(query("a", "b;c", ts)))
+	// different clause
+	assert.NotEqual(t, k, key(Select("a").From("table").Where(Eq("b", 1)).Where(Eq("c", "x")).Where("d NOT IN ?",
+		Select("d").From("table2").Where(Eq("e", ts)))))
+	// values behind pointers
+	x, y := "x", "x"
+	assert.Equal(t, key(query(1, &x, ts)), key(query(1, &y, ts)))
+	assert.Equal(t, key(Eq("a", []int{1, 2})), key(Eq("a", []int{1, 2})))
+	assert.NotEqual(t, key(Eq("a", []int{1, 2})), key(Eq("a", []int{1, 3})))
+	// elements of bound slices, e.g. arrays of drivers, are encoded by value
+	a, b := "a", "a"
+	assert.Equal(t, key(Expr("? = ANY(x)", [1][]*string{{&a}})), key(Expr("? = ANY(x)", [1][]*string{{&b}})))
+
+	// values which can not be encoded by value
+	for _, v := range []interface{}{struct{ A *int }{}, make(chan int)} {
+		_, err := CacheKey(dialect.PostgreSQL, Eq("a", v))
+		assert.Equal(t, ErrInvalidCacheValue, err)
+	}
+}
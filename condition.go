@@ -1,36 +1,190 @@
 package dbr
 
-import "reflect"
+import (
+	"bytes"
+	"reflect"
+	"sort"
+	"time"
+)
+
+// predicate is a condition which never needs parentheses, e.g. `a = ?`
+type predicate BuildFunc
+
+func (p predicate) Build(d Dialect, buf Buffer) error {
+	return p(d, buf)
+}
+
+// True and False are literal conditions, e.g. to start a condition built in a loop.
+// And skips True and is False if any of its conditions is False,
+// Or skips False and is True if any of its conditions is True.
+// An empty And is True and an empty Or is False.
+var (
+	True  Builder = boolCond(true)
+	False Builder = boolCond(false)
+)
+
+type boolCond bool
+
+func (b boolCond) Build(d Dialect, buf Buffer) error {
+	buf.WriteString(encodeCond(d, bool(b)))
+	return nil
+}
+
+func encodeCond(d Dialect, b bool) string {
+	if c, ok := baseDialect(d).(CondDialect); ok {
+		return c.EncodeCond(b)
+	}
+	return d.EncodeBool(b)
+}
+
+// constCond returns the value of cond if it is known without the database
+func constCond(cond Builder) (boolCond, bool) {
+	switch cond := cond.(type) {
+	case boolCond:
+		return cond, true
+	case *junction:
+		cond = cond.simplify()
+		if len(cond.cond) == 1 {
+			return constCond(cond.cond[0])
+		}
+	}
+	return false, false
+}
+
+// matchAllRows reports whether WHERE of cond is missing or always true
+func matchAllRows(cond []Builder) bool {
+	v, ok := constCond(And(cond...))
+	return ok && bool(v)
+}
+
+// junction joins conditions with AND or OR
+type junction struct {
+	pred string
+	cond []Builder
+}
+
+func (j *junction) Build(d Dialect, buf Buffer) error {
+	return buildCond(d, buf, j.pred, j.cond...)
+}
+
+// simplify removes constant conditions which don't change the result of j,
+// and reduces j to a single boolCond if the result is constant
+func (j *junction) simplify() *junction {
+	identity := boolCond(j.pred == "AND")
+	cond := make([]Builder, 0, len(j.cond))
+	for _, c := range j.cond {
+		v, ok := constCond(c)
+		if !ok {
+			cond = append(cond, c)
+			continue
+		}
+		if v != identity {
+			return &junction{pred: j.pred, cond: []Builder{v}}
+		}
+	}
+	if len(cond) == 0 {
+		cond = append(cond, identity)
+	}
+	return &junction{pred: j.pred, cond: cond}
+}
+
+// junctionPred returns the operator which binds cond at the top level:
+// "" for a predicate, AND or OR for a junction, and "?" if unknown.
+func junctionPred(cond Builder) string {
+	switch cond := cond.(type) {
+	case predicate, boolCond:
+		return ""
+	case *junction:
+		cond = cond.simplify()
+		if len(cond.cond) == 1 {
+			return junctionPred(cond.cond[0])
+		}
+		return cond.pred
+	}
+	return "?"
+}
+
+// needParentheses reports whether cond must be parenthesized when joined with pred.
+// Without minimal parentheses each condition is parenthesized.
+func needParentheses(d Dialect, pred string, n int, cond Builder) bool {
+	if !isMinimalParentheses(d) {
+		return true
+	}
+	if n == 1 {
+		return false
+	}
+	switch junctionPred(cond) {
+	case "", "AND":
+		// AND binds tighter than OR
+		return false
+	case "OR":
+		return pred != "OR"
+	}
+	return true
+}
+
+type minimalParentheses struct {
+	Dialect
+}
+
+func (d minimalParentheses) unwrap() Dialect {
+	return d.Dialect
+}
+
+// MinimalParentheses wraps d to parenthesize conditions only where precedence requires,
+// e.g. `WHERE a = ? AND (b = ? OR c = ?)` instead of `WHERE (a = ?) AND ((b = ?) OR (c = ?))`.
+// Expr conditions are always parenthesized next to other conditions.
+func MinimalParentheses(d Dialect) Dialect {
+	return minimalParentheses{Dialect: d}
+}
+
+func isMinimalParentheses(d Dialect) bool {
+	for {
+		switch w := d.(type) {
+		case minimalParentheses:
+			return true
+		case dialectWrapper:
+			d = w.unwrap()
+		default:
+			return false
+		}
+	}
+}
 
 func buildCond(d Dialect, buf Buffer, pred string, cond ...Builder) error {
+	cond = (&junction{pred: pred, cond: cond}).simplify().cond
+	if v, ok := cond[0].(boolCond); ok && len(cond) == 1 {
+		return v.Build(d, buf)
+	}
 	for i, c := range cond {
 		if i > 0 {
 			buf.WriteString(" ")
 			buf.WriteString(pred)
 			buf.WriteString(" ")
 		}
-		buf.WriteString("(")
+		paren := needParentheses(d, pred, len(cond), c)
+		if paren {
+			buf.WriteString("(")
+		}
 		err := c.Build(d, buf)
 		if err != nil {
 			return err
 		}
-		buf.WriteString(")")
+		if paren {
+			buf.WriteString(")")
+		}
 	}
 	return nil
 }
 
 // And creates AND from a list of conditions
 func And(cond ...Builder) Builder {
-	return BuildFunc(func(d Dialect, buf Buffer) error {
-		return buildCond(d, buf, "AND", cond...)
-	})
+	return &junction{pred: "AND", cond: cond}
 }
 
 // Or creates OR from a list of conditions
 func Or(cond ...Builder) Builder {
-	return BuildFunc(func(d Dialect, buf Buffer) error {
-		return buildCond(d, buf, "OR", cond...)
-	})
+	return &junction{pred: "OR", cond: cond}
 }
 
 func buildCmp(d Dialect, buf Buffer, pred, column string, value interface{}) error {
@@ -46,19 +200,19 @@ func buildCmp(d Dialect, buf Buffer, pred, column string, value interface{}) err
 
 // Eq is `=`.
 // When value is nil, it will be translated to `IS NULL`.
-// When value is a slice, it will be translated to `IN`.
+// When value is a slice or map (or a pointer to one), it will be translated to `IN`, or false if it is empty.
+// []byte and driver.Valuer are compared as values.
 // Otherwise it will be translated to `=`.
 func Eq(column string, value interface{}) Builder {
-	return BuildFunc(func(d Dialect, buf Buffer) error {
+	return predicate(func(d Dialect, buf Buffer) error {
 		if value == nil {
 			buf.WriteString(d.QuoteIdent(column))
 			buf.WriteString(" IS NULL")
 			return nil
 		}
-		v := reflect.ValueOf(value)
-		if v.Kind() == reflect.Slice || v.Kind() == reflect.Map {
+		if v, ok := listValue(value); ok {
 			if v.Len() == 0 {
-				buf.WriteString(d.EncodeBool(false))
+				buf.WriteString(encodeCond(d, false))
 				return nil
 			}
 			return buildCmp(d, buf, "IN", column, value)
@@ -69,19 +223,18 @@ func Eq(column string, value interface{}) Builder {
 
 // Neq is `!=`.
 // When value is nil, it will be translated to `IS NOT NULL`.
-// When value is a slice, it will be translated to `NOT IN`.
+// When value is a slice or map (or a pointer to one), it will be translated to `NOT IN`, or true if it is empty.
 // Otherwise it will be translated to `!=`.
 func Neq(column string, value interface{}) Builder {
-	return BuildFunc(func(d Dialect, buf Buffer) error {
+	return predicate(func(d Dialect, buf Buffer) error {
 		if value == nil {
 			buf.WriteString(d.QuoteIdent(column))
 			buf.WriteString(" IS NOT NULL")
 			return nil
 		}
-		v := reflect.ValueOf(value)
-		if v.Kind() == reflect.Slice || v.Kind() == reflect.Map {
+		if v, ok := listValue(value); ok {
 			if v.Len() == 0 {
-				buf.WriteString(d.EncodeBool(true))
+				buf.WriteString(encodeCond(d, true))
 				return nil
 			}
 			return buildCmp(d, buf, "NOT IN", column, value)
@@ -92,28 +245,328 @@ func Neq(column string, value interface{}) Builder {
 
 // Gt is `>`.
 func Gt(column string, value interface{}) Builder {
-	return BuildFunc(func(d Dialect, buf Buffer) error {
+	return predicate(func(d Dialect, buf Buffer) error {
 		return buildCmp(d, buf, ">", column, value)
 	})
 }
 
+// IsDistinctFrom is `column IS DISTINCT FROM value`, which compares NULLs as equal values,
+// e.g. to detect changes of nullable columns. value can be a column, e.g. I("old.name").
+// It is emulated with `<=>` in MySQL, IS NOT in SQLite and DECODE in Oracle.
+func IsDistinctFrom(column string, value interface{}) Builder {
+	return predicate(func(d Dialect, buf Buffer) error {
+		return buildDistinctFrom(d, buf, column, value, false)
+	})
+}
+
+// IsNotDistinctFrom is `column IS NOT DISTINCT FROM value`, the NULL-safe equality, see IsDistinctFrom
+func IsNotDistinctFrom(column string, value interface{}) Builder {
+	return predicate(func(d Dialect, buf Buffer) error {
+		return buildDistinctFrom(d, buf, column, value, true)
+	})
+}
+
+func buildDistinctFrom(d Dialect, buf Buffer, column string, value interface{}, not bool) error {
+	df, ok := baseDialect(d).(DistinctFromDialect)
+	if !ok {
+		return ErrNotSupported
+	}
+	cmp := df.DistinctFrom(d.QuoteIdent(column), placeholder, not)
+	if cmp == "" {
+		return ErrNotSupported
+	}
+	buf.WriteString(cmp)
+	return buf.WriteValue(value)
+}
+
+// Like is `column LIKE pattern ESCAPE '\'`. Wildcards of user input in pattern can be
+// escaped with EscapeLike, e.g. Like("name", "%"+EscapeLike(search)+"%").
+func Like(column, pattern string) Builder {
+	return predicate(func(d Dialect, buf Buffer) error {
+		return buildLike(d, buf, column, pattern, '\\')
+	})
+}
+
+// LikeEscape matches column with value literally using LIKE: wildcards % and _ in value
+// are escaped with escape, which is declared by `ESCAPE 'escape'`.
+// ClickHouse has no ESCAPE and supports only backslash, other escapes return ErrNotSupported.
+func LikeEscape(column, value string, escape rune) Builder {
+	return predicate(func(d Dialect, buf Buffer) error {
+		return buildLike(d, buf, column, escapeLike(value, escape), escape)
+	})
+}
+
+// EscapeLike escapes LIKE wildcards % and _ and backslash in s with backslash, see Like
+func EscapeLike(s string) string {
+	return escapeLike(s, '\\')
+}
+
+func escapeLike(s string, escape rune) string {
+	buf := new(bytes.Buffer)
+	for _, c := range s {
+		if c == '%' || c == '_' || c == escape {
+			buf.WriteRune(escape)
+		}
+		buf.WriteRune(c)
+	}
+	return buf.String()
+}
+
+func buildLike(d Dialect, buf Buffer, column, pattern string, escape rune) error {
+	var clause string
+	if l, ok := baseDialect(d).(LikeEscapeDialect); ok {
+		clause = l.LikeEscape(string(escape))
+	}
+	if clause == "" && escape != '\\' {
+		return ErrNotSupported
+	}
+	err := buildCmp(d, buf, "LIKE", column, pattern)
+	if err != nil {
+		return err
+	}
+	if clause != "" {
+		buf.WriteString(" ")
+		buf.WriteString(clause)
+	}
+	return nil
+}
+
+// Unaccent matches column with the LIKE pattern value ignoring accents and case,
+// e.g. Unaccent("name", "jose%") matches "José".
+// PostgreSQL renders `unaccent(column) ILIKE unaccent(?)`, which needs the unaccent extension
+// (CREATE EXTENSION unaccent). MySQL renders `column LIKE ?` and relies on an accent-insensitive
+// collation of the column, e.g. utf8mb4_0900_ai_ci. Other dialects return ErrNotSupported.
+func Unaccent(column string, value interface{}) Builder {
+	return predicate(func(d Dialect, buf Buffer) error {
+		u, ok := baseDialect(d).(UnaccentDialect)
+		if !ok {
+			return ErrNotSupported
+		}
+		s := u.Unaccent(d.QuoteIdent(column), placeholder)
+		if s == "" {
+			return ErrNotSupported
+		}
+		buf.WriteString(s)
+		buf.WriteValue(value)
+		return nil
+	})
+}
+
 // Gte is '>='.
 func Gte(column string, value interface{}) Builder {
-	return BuildFunc(func(d Dialect, buf Buffer) error {
+	return predicate(func(d Dialect, buf Buffer) error {
 		return buildCmp(d, buf, ">=", column, value)
 	})
 }
 
 // Lt is '<'.
 func Lt(column string, value interface{}) Builder {
-	return BuildFunc(func(d Dialect, buf Buffer) error {
+	return predicate(func(d Dialect, buf Buffer) error {
 		return buildCmp(d, buf, "<", column, value)
 	})
 }
 
 // Lte is `<=`.
 func Lte(column string, value interface{}) Builder {
-	return BuildFunc(func(d Dialect, buf Buffer) error {
+	return predicate(func(d Dialect, buf Buffer) error {
 		return buildCmp(d, buf, "<=", column, value)
 	})
 }
+
+// Exists is `EXISTS (subquery)`.
+func Exists(subquery Builder) Builder {
+	return predicate(func(d Dialect, buf Buffer) error {
+		buf.WriteString("EXISTS (")
+		err := subquery.Build(d, buf)
+		if err != nil {
+			return err
+		}
+		buf.WriteString(")")
+		return nil
+	})
+}
+
+// InTuple is `(column1, column2) IN ((?,?),(?,?))`, a row value comparison
+// e.g. for composite keys. Values are bound in row-major order.
+// When value is empty, it will be translated to false.
+// Dialects without row value IN get `((column1 = ?) AND (column2 = ?)) OR (...)` instead.
+func InTuple(column []string, value [][]interface{}) Builder {
+	return BuildFunc(func(d Dialect, buf Buffer) error {
+		if len(column) == 0 {
+			return ErrColumnNotSpecified
+		}
+		for _, tuple := range value {
+			if len(tuple) != len(column) {
+				return ErrTupleLength
+			}
+		}
+		if len(value) == 0 {
+			buf.WriteString(encodeCond(d, false))
+			return nil
+		}
+		if t, ok := baseDialect(d).(TupleInDialect); !ok || !t.SupportsTupleIn() {
+			return tupleOr(column, value).Build(d, buf)
+		}
+
+		buf.WriteString("(")
+		for i, col := range column {
+			if i > 0 {
+				buf.WriteString(", ")
+			}
+			buf.WriteString(d.QuoteIdent(col))
+		}
+		buf.WriteString(") IN (")
+		for i, tuple := range value {
+			if i > 0 {
+				buf.WriteString(",")
+			}
+			buf.WriteString("(")
+			for j := range tuple {
+				if j > 0 {
+					buf.WriteString(",")
+				}
+				buf.WriteString(placeholder)
+			}
+			buf.WriteString(")")
+			buf.WriteValue(tuple...)
+		}
+		buf.WriteString(")")
+		return nil
+	})
+}
+
+func tupleOr(column []string, value [][]interface{}) Builder {
+	or := make([]Builder, len(value))
+	for i, tuple := range value {
+		and := make([]Builder, len(column))
+		for j := range column {
+			col, v := column[j], tuple[j]
+			and[j] = predicate(func(d Dialect, buf Buffer) error {
+				return buildCmp(d, buf, "=", col, v)
+			})
+		}
+		or[i] = And(and...)
+	}
+	return Or(or...)
+}
+
+// StructCond returns `column = value` conditions joined with AND for non-zero fields of filter,
+// a struct or a pointer to struct. Columns are named as in loading, by db tags or in snake case.
+// Pointer fields are included unless they are nil, so a pointer to a zero value matches zero.
+// Slice and map fields other than []byte and driver.Valuer are skipped, see StructCondIn.
+// Columns are sorted by name.
+func StructCond(filter interface{}) Builder {
+	return And(structCond(filter, false)...)
+}
+
+// StructCondIn is StructCond with `column IN (...)` conditions for non-nil slice and map fields,
+// a non-nil empty slice matches no rows.
+func StructCondIn(filter interface{}) Builder {
+	return And(structCond(filter, true)...)
+}
+
+var typeTime = reflect.TypeOf(time.Time{})
+
+func structCond(filter interface{}, in bool) []Builder {
+	v := reflect.Indirect(reflect.ValueOf(filter))
+	if v.Kind() != reflect.Struct {
+		return []Builder{BuildFunc(func(Dialect, Buffer) error {
+			return ErrNotStruct
+		})}
+	}
+	sm := structMap(v.Type())
+	column := make([]string, 0, len(sm))
+	for col := range sm {
+		column = append(column, col)
+	}
+	sort.Strings(column)
+
+	var cond []Builder
+	for _, col := range column {
+		field, ok := fieldByIndex(v, sm[col])
+		if !ok {
+			continue
+		}
+		if field.Kind() == reflect.Ptr {
+			if field.IsNil() {
+				continue
+			}
+			if !field.Type().Implements(typeValuer) {
+				field = field.Elem()
+			}
+		} else if isZero(field) {
+			continue
+		}
+
+		t := field.Type()
+		switch {
+		case t.Implements(typeValuer), t == typeTime:
+		case t.Kind() == reflect.Struct:
+			// fields of nested structs are in sm
+			continue
+		case t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8:
+		case t.Kind() == reflect.Slice, t.Kind() == reflect.Map:
+			if !in || field.IsNil() {
+				continue
+			}
+		}
+		if !field.CanInterface() {
+			// unexported embedded struct, its exported fields are in sm
+			continue
+		}
+		cond = append(cond, Eq(col, field.Interface()))
+	}
+	return cond
+}
+
+// fieldByIndex is reflect.Value.FieldByIndex which returns false for nil embedded pointers
+func fieldByIndex(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, true
+}
+
+// isZero reports whether v is the zero value of its type, slices and maps are zero if they are nil.
+// It does not call Interface, so v can be a field of an unexported embedded struct.
+func isZero(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map, reflect.Func, reflect.Chan, reflect.Interface, reflect.Ptr:
+		return v.IsNil()
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Complex64, reflect.Complex128:
+		return v.Complex() == 0
+	case reflect.String:
+		return v.Len() == 0
+	case reflect.UnsafePointer:
+		return v.Pointer() == 0
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if !isZero(v.Index(i)) {
+				return false
+			}
+		}
+		return true
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if !isZero(v.Field(i)) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
@@ -1,7 +1,9 @@
 package dbr
 
 import (
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/lianchengwu/dbr/dialect"
 	"github.com/stretchr/testify/assert"
@@ -101,3 +103,395 @@ func TestCondition(t *testing.T) {
 		assert.Equal(t, test.value, buf.Value())
 	}
 }
+
+func TestInTuple(t *testing.T) {
+	cond := InTuple([]string{"tenant_id", "user_id"}, [][]interface{}{{1, 2}, {3, 4}})
+
+	buf := NewBuffer()
+	err := cond.Build(dialect.MySQL, buf)
+	assert.NoError(t, err)
+	assert.Equal(t, "(`tenant_id`, `user_id`) IN ((?,?),(?,?))", buf.String())
+	assert.Equal(t, []interface{}{1, 2, 3, 4}, buf.Value())
+
+	buf = NewBuffer()
+	err = cond.Build(dialect.SQLite3, buf)
+	assert.NoError(t, err)
+	assert.Equal(t, `(("tenant_id" = ?) AND ("user_id" = ?)) OR (("tenant_id" = ?) AND ("user_id" = ?))`, buf.String())
+	assert.Equal(t, []interface{}{1, 2, 3, 4}, buf.Value())
+
+	buf = NewBuffer()
+	err = InTuple([]string{"a", "b"}, nil).Build(dialect.MySQL, buf)
+	assert.NoError(t, err)
+	assert.Equal(t, "0", buf.String())
+
+	err = InTuple([]string{"a", "b"}, [][]interface{}{{1}}).Build(dialect.MySQL, NewBuffer())
+	assert.Equal(t, ErrTupleLength, err)
+}
+
+func TestMinimalParentheses(t *testing.T) {
+	d := MinimalParentheses(dialect.MySQL)
+	for _, test := range []struct {
+		builder Builder
+		query   string
+	}{
+		{
+			builder: Select("a").From("table").Where(Eq("b", 1)),
+			query:   "SELECT a FROM table WHERE `b` = ?",
+		},
+		{
+			builder: Select("a").From("table").Where(Eq("b", 1)).Where(Or(Gt("c", 2), Lt("d", 3))),
+			query:   "SELECT a FROM table WHERE `b` = ? AND (`c` > ? OR `d` < ?)",
+		},
+		{
+			builder: Or(And(Eq("a", 1), Eq("b", 2)), Eq("c", 3)),
+			query:   "`a` = ? AND `b` = ? OR `c` = ?",
+		},
+		{
+			builder: And(Eq("a", 1), And(Or(Eq("b", 2), Eq("c", 3)))),
+			query:   "`a` = ? AND (`b` = ? OR `c` = ?)",
+		},
+		{
+			builder: And(Eq("a", 1), Expr("b = ? OR c = ?", 2, 3)),
+			query:   "`a` = ? AND (b = ? OR c = ?)",
+		},
+		{
+			builder: Update("table").Set("a", 1).Where(Expr("b = ? OR c = ?", 2, 3)),
+			query:   "UPDATE `table` SET `a` = ? WHERE b = ? OR c = ?",
+		},
+	} {
+		buf := NewBuffer()
+		err := test.builder.Build(d, buf)
+		assert.NoError(t, err)
+		assert.Equal(t, test.query, buf.String())
+	}
+}
+
+func TestConstCondition(t *testing.T) {
+	for _, test := range []struct {
+		cond  Builder
+		query string
+		value []interface{}
+	}{
+		{
+			cond:  And(),
+			query: "1",
+		},
+		{
+			cond:  Or(),
+			query: "0",
+		},
+		{
+			cond:  And(Eq("a", 1), And(), True),
+			query: "(`a` = ?)",
+			value: []interface{}{1},
+		},
+		{
+			cond:  And(Eq("a", 1), Or(), Eq("b", 2)),
+			query: "0",
+		},
+		{
+			cond:  Or(Eq("a", 1), Or(), False, And(Eq("b", 2), True)),
+			query: "(`a` = ?) OR ((`b` = ?))",
+			value: []interface{}{1, 2},
+		},
+		{
+			cond:  Or(Eq("a", 1), And()),
+			query: "1",
+		},
+		{
+			cond:  And(Eq("a", 1), Or(Eq("b", 2), And(Eq("c", 3), False))),
+			query: "(`a` = ?) AND ((`b` = ?))",
+			value: []interface{}{1, 2},
+		},
+	} {
+		buf := NewBuffer()
+		err := test.cond.Build(dialect.MySQL, buf)
+		assert.NoError(t, err)
+		assert.Equal(t, test.query, buf.String())
+		assert.Equal(t, test.value, buf.Value())
+	}
+
+	buf := NewBuffer()
+	err := Select("a").From("table").Where(And()).Where(Eq("b", 1)).Build(dialect.PostgreSQL, buf)
+	assert.NoError(t, err)
+	assert.Equal(t, `SELECT a FROM table WHERE ("b" = ?)`, buf.String())
+
+	buf = NewBuffer()
+	err = Select("a").From("table").Where(Or()).Build(dialect.PostgreSQL, buf)
+	assert.NoError(t, err)
+	assert.Equal(t, `SELECT a FROM table WHERE FALSE`, buf.String())
+
+	buf = NewBuffer()
+	err = Or(Eq("a", 1), And(Eq("b", 2), Eq("c", 3), True)).Build(MinimalParentheses(dialect.MySQL), buf)
+	assert.NoError(t, err)
+	assert.Equal(t, "`a` = ? OR `b` = ? AND `c` = ?", buf.String())
+
+	// oracle has no boolean expressions
+	for _, test := range []struct {
+		cond  Builder
+		query string
+	}{
+		{cond: Eq("a", []int{}), query: `SELECT a FROM table WHERE (1 = 0)`},
+		{cond: Neq("a", []int{}), query: `SELECT a FROM table WHERE (1 = 1)`},
+		{cond: And(), query: `SELECT a FROM table WHERE 1 = 1`},
+		{cond: Or(Eq("a", 1), False), query: `SELECT a FROM table WHERE (("a" = ?))`},
+		{cond: InTuple([]string{"a", "b"}, nil), query: `SELECT a FROM table WHERE (1 = 0)`},
+	} {
+		buf = NewBuffer()
+		err = Select("a").From("table").Where(test.cond).Build(dialect.Oracle, buf)
+		assert.NoError(t, err)
+		assert.Equal(t, test.query, buf.String())
+	}
+}
+
+func TestExists(t *testing.T) {
+	buf := NewBuffer()
+	err := Select("id").From("users").
+		Where(Eq("active", true)).
+		Where(Exists(Select("1").From("orders").Where("orders.user_id = users.id AND orders.total > ?", 100))).
+		Where(Eq("country", "nz")).
+		Build(dialect.PostgreSQL, buf)
+	assert.NoError(t, err)
+	assert.Equal(t, `SELECT id FROM users WHERE ("active" = ?) AND (EXISTS (SELECT 1 FROM orders WHERE (orders.user_id = users.id AND orders.total > ?))) AND ("country" = ?)`, buf.String())
+	assert.Equal(t, []interface{}{true, 100, "nz"}, buf.Value())
+}
+
+type testFilterPage struct {
+	Limit int `db:"-"`
+}
+
+type testFilter struct {
+	*testFilterPage
+
+	Status    string
+	Priority  int
+	Archived  *bool
+	OwnerID   *int64 `db:"owner"`
+	Tags      []string
+	Name      NullString
+	CreatedAt time.Time
+}
+
+type testFilterTenant struct {
+	TenantID int64
+}
+
+type testFilterEmbedded struct {
+	testFilterTenant
+	Status string
+}
+
+func TestStructCond(t *testing.T) {
+	zero := int64(0)
+	archived := false
+	for _, test := range []struct {
+		cond  Builder
+		query string
+		value []interface{}
+	}{
+		{
+			// zero fields are skipped, pointers to zero are included
+			cond:  StructCond(&testFilter{Status: "active", OwnerID: &zero, Archived: &archived}),
+			query: "(`archived` = ?) AND (`owner` = ?) AND (`status` = ?)",
+			value: []interface{}{false, int64(0), "active"},
+		},
+		{
+			cond:  StructCond(testFilter{Priority: 2, Tags: []string{"a", "b"}, Name: NewNullString("x")}),
+			query: "(`name` = ?) AND (`priority` = ?)",
+			value: []interface{}{NewNullString("x"), 2},
+		},
+		{
+			cond:  StructCondIn(&testFilter{Tags: []string{"a", "b"}, CreatedAt: time.Unix(1, 0).UTC()}),
+			query: "(`created_at` = ?) AND (`tags` IN ?)",
+			value: []interface{}{time.Unix(1, 0).UTC(), []string{"a", "b"}},
+		},
+		{
+			// empty slices match no rows
+			cond:  StructCondIn(&testFilter{Status: "active", Tags: []string{}}),
+			query: "(`status` = ?) AND (0)",
+			value: []interface{}{"active"},
+		},
+		{
+			cond:  StructCond(&testFilter{testFilterPage: &testFilterPage{Limit: 10}}),
+			query: "1",
+		},
+		{
+			// fields of unexported embedded structs are columns
+			cond:  StructCond(&testFilterEmbedded{testFilterTenant{1}, "a"}),
+			query: "(`status` = ?) AND (`tenant_id` = ?)",
+			value: []interface{}{"a", int64(1)},
+		},
+		{
+			cond:  StructCond(testFilterEmbedded{Status: "a"}),
+			query: "(`status` = ?)",
+			value: []interface{}{"a"},
+		},
+	} {
+		buf := NewBuffer()
+		err := test.cond.Build(dialect.PostgreSQL, NewBuffer())
+		assert.NoError(t, err)
+		err = test.cond.Build(dialect.MySQL, buf)
+		assert.NoError(t, err)
+		assert.Equal(t, test.query, buf.String())
+		assert.Equal(t, test.value, buf.Value())
+	}
+
+	buf := NewBuffer()
+	err := Select("id").From("tasks").WhereStruct(&testFilter{Status: "active"}).Where(Gt("id", 3)).Build(dialect.PostgreSQL, buf)
+	assert.NoError(t, err)
+	assert.Equal(t, `SELECT id FROM tasks WHERE ("status" = ?) AND ("id" > ?)`, buf.String())
+	assert.Equal(t, []interface{}{"active", 3}, buf.Value())
+
+	err = StructCond("status").Build(dialect.MySQL, NewBuffer())
+	assert.Equal(t, ErrNotStruct, err)
+}
+
+func TestUnaccent(t *testing.T) {
+	buf := NewBuffer()
+	err := Select("id").From("people").Where(Unaccent("name", "jose%")).Where(Eq("active", true)).Build(dialect.PostgreSQL, buf)
+	assert.NoError(t, err)
+	assert.Equal(t, `SELECT id FROM people WHERE (unaccent("name") ILIKE unaccent(?)) AND ("active" = ?)`, buf.String())
+	assert.Equal(t, []interface{}{"jose%", true}, buf.Value())
+
+	query, err := InterpolateForDialect("?", []interface{}{Unaccent("name", "jose%")}, dialect.PostgreSQL)
+	assert.NoError(t, err)
+	assert.Equal(t, `unaccent("name") ILIKE unaccent('jose%')`, query)
+
+	buf = NewBuffer()
+	err = Unaccent("name", "jose%").Build(dialect.MySQL, buf)
+	assert.NoError(t, err)
+	assert.Equal(t, "`name` LIKE ?", buf.String())
+
+	err = Unaccent("name", "jose%").Build(dialect.SQLite3, NewBuffer())
+	assert.Equal(t, ErrNotSupported, err)
+}
+
+func TestLikeEscape(t *testing.T) {
+	assert.Equal(t, `50\% off\_sale \\`, EscapeLike(`50% off_sale \`))
+
+	for _, test := range []struct {
+		dialect Dialect
+		cond    Builder
+		query   string
+	}{
+		{
+			dialect: dialect.MySQL,
+			cond:    Like("name", "%"+EscapeLike("50%")+"%"),
+			query:   "`name` LIKE '%50\\\\%%' ESCAPE '\\\\'",
+		},
+		{
+			dialect: dialect.PostgreSQL,
+			cond:    LikeEscape("name", "50%_!", '!'),
+			query:   `"name" LIKE '50!%!_!!' ESCAPE '!'`,
+		},
+		{
+			dialect: dialect.ClickHouse,
+			cond:    LikeEscape("name", "50%", '\\'),
+			query:   "`name` LIKE '50\\\\%'",
+		},
+	} {
+		query, err := InterpolateForDialect("?", []interface{}{test.cond}, test.dialect)
+		assert.NoError(t, err)
+		assert.Equal(t, test.query, query)
+	}
+	_, err := InterpolateForDialect("?", []interface{}{LikeEscape("name", "50%", '!')}, dialect.ClickHouse)
+	assert.Equal(t, ErrNotSupported, err)
+
+	for _, sess := range testSession {
+		key := fmt.Sprintf("like_%d_", nextID())
+		for _, v := range []string{"50% off", "50x off", "5_0"} {
+			_, err := sess.InsertInto("dbr_keys").Columns("key_value", "val_value").Values(key+v, v).Exec()
+			assert.NoError(t, err)
+		}
+		for _, test := range []struct {
+			cond Builder
+			want []string
+		}{
+			{cond: Like("val_value", "50%"), want: []string{"50% off", "50x off"}},
+			{cond: Like("val_value", EscapeLike("50%")+"%"), want: []string{"50% off"}},
+			{cond: LikeEscape("val_value", "50% off", '\\'), want: []string{"50% off"}},
+			{cond: Like("val_value", "5_0"), want: []string{"5_0"}},
+			{cond: LikeEscape("val_value", "50%", '\\'), want: nil},
+		} {
+			var values []string
+			_, err := sess.Select("val_value").From("dbr_keys").
+				Where(Eq("key_value", []string{key + "50% off", key + "50x off", key + "5_0"})).
+				Where(test.cond).
+				OrderAsc("val_value").
+				Load(&values)
+			assert.NoError(t, err)
+			assert.Equal(t, test.want, values)
+		}
+	}
+}
+
+func TestIsDistinctFrom(t *testing.T) {
+	for _, test := range []struct {
+		dialect Dialect
+		cond    Builder
+		query   string
+	}{
+		{
+			dialect: dialect.PostgreSQL,
+			cond:    IsDistinctFrom("old.name", I("new.name")),
+			query:   `"old"."name" IS DISTINCT FROM "new"."name"`,
+		},
+		{
+			dialect: dialect.PostgreSQL,
+			cond:    IsNotDistinctFrom("name", nil),
+			query:   `"name" IS NOT DISTINCT FROM NULL`,
+		},
+		{
+			dialect: dialect.MySQL,
+			cond:    IsDistinctFrom("old.name", I("new.name")),
+			query:   "NOT (`old`.`name` <=> `new`.`name`)",
+		},
+		{
+			dialect: dialect.MySQL,
+			cond:    IsNotDistinctFrom("name", "a"),
+			query:   "`name` <=> 'a'",
+		},
+		{
+			dialect: dialect.SQLite3,
+			cond:    IsDistinctFrom("name", "a"),
+			query:   `"name" IS NOT 'a'`,
+		},
+		{
+			dialect: dialect.Oracle,
+			cond:    IsDistinctFrom("name", "a"),
+			query:   `DECODE("name", 'a', 0, 1) = 1`,
+		},
+	} {
+		query, err := InterpolateForDialect("?", []interface{}{test.cond}, test.dialect)
+		assert.NoError(t, err)
+		assert.Equal(t, test.query, query)
+	}
+	_, err := InterpolateForDialect("?", []interface{}{IsDistinctFrom("name", "a")}, dialect.ClickHouse)
+	assert.Equal(t, ErrNotSupported, err)
+
+	for _, sess := range testSession {
+		if sess.Dialect == dialect.ClickHouse {
+			continue
+		}
+		key := fmt.Sprintf("distinct_%d_", nextID())
+		_, err := sess.InsertInto("dbr_keys").Columns("key_value", "val_value").
+			Values(key+"null", nil).Values(key+"a", "a").Values(key+"same", key+"same").Exec()
+		assert.NoError(t, err)
+		for _, test := range []struct {
+			cond Builder
+			want []string
+		}{
+			{cond: IsDistinctFrom("val_value", "a"), want: []string{key + "null", key + "same"}},
+			{cond: IsNotDistinctFrom("val_value", nil), want: []string{key + "null"}},
+			{cond: IsDistinctFrom("val_value", I("key_value")), want: []string{key + "a", key + "null"}},
+			{cond: IsNotDistinctFrom("val_value", I("key_value")), want: []string{key + "same"}},
+		} {
+			var got []string
+			_, err := sess.Select("key_value").From("dbr_keys").
+				Where(Like("key_value", EscapeLike(key)+"%")).Where(test.cond).
+				OrderAsc("key_value").Load(&got)
+			assert.NoError(t, err)
+			assert.Equal(t, test.want, got)
+		}
+	}
+}
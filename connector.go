@@ -0,0 +1,112 @@
+package dbr
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+)
+
+// ConnInit initializes a new connection of the pool before it is used, see InitConnector
+type ConnInit func(ctx context.Context, conn driver.Conn) error
+
+// ExecInit returns a ConnInit executing query on the connection,
+// e.g. "SET TIME ZONE 'UTC'" or "SET SESSION sql_mode = 'STRICT_ALL_TABLES'"
+func ExecInit(query ...string) ConnInit {
+	return func(ctx context.Context, conn driver.Conn) error {
+		for _, q := range query {
+			err := execConn(ctx, conn, q)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func execConn(ctx context.Context, conn driver.Conn, query string) error {
+	if execer, ok := conn.(driver.ExecerContext); ok {
+		_, err := execer.ExecContext(ctx, query, nil)
+		if err != driver.ErrSkip {
+			return err
+		}
+	}
+	stmt, err := conn.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+	if execer, ok := stmt.(driver.StmtExecContext); ok {
+		_, err = execer.ExecContext(ctx, nil)
+		return err
+	}
+	_, err = stmt.Exec(nil)
+	return err
+}
+
+type initConnector struct {
+	driver.Connector
+	init []ConnInit
+}
+
+func (c *initConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.Connector.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, init := range c.init {
+		err = init(ctx, conn)
+		if err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+	return conn, nil
+}
+
+// InitConnector wraps c so that init runs on each new connection before the pool hands it out,
+// so session variables set by init apply to all queries. Use it with sql.OpenDB.
+func InitConnector(c driver.Connector, init ...ConnInit) driver.Connector {
+	return &initConnector{Connector: c, init: init}
+}
+
+type dsnConnector struct {
+	dsn string
+	drv driver.Driver
+}
+
+func (c *dsnConnector) Connect(context.Context) (driver.Conn, error) {
+	return c.drv.Open(c.dsn)
+}
+
+func (c *dsnConnector) Driver() driver.Driver {
+	return c.drv
+}
+
+// OpenWithInit is Open with init running on each new connection, see InitConnector
+func OpenWithInit(driverName, dsn string, log EventReceiver, init ...ConnInit) (*Connection, error) {
+	if log == nil {
+		log = nullReceiver
+	}
+	// sql.Open does not connect, it is used to look up the registered driver
+	db, err := sql.Open(driverName, dsn)
+	if err != nil {
+		return nil, err
+	}
+	drv := db.Driver()
+	db.Close()
+	d, err := driverDialect(driverName)
+	if err != nil {
+		return nil, err
+	}
+
+	var c driver.Connector
+	if dc, ok := drv.(driver.DriverContext); ok {
+		c, err = dc.OpenConnector(dsn)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		c = &dsnConnector{dsn: dsn, drv: drv}
+	}
+	return &Connection{DB: sql.OpenDB(InitConnector(c, init...)), EventReceiver: log, Dialect: d}, nil
+}
@@ -0,0 +1,55 @@
+package dbr
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+
+	"github.com/lianchengwu/dbr/dialect"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInitConnector(t *testing.T) {
+	fake := &fakeDriver{columns: []string{"a"}, rows: [][]driver.Value{{int64(1)}}}
+	db := sql.OpenDB(InitConnector(fake, ExecInit("SET TIME ZONE 'UTC'", "SET statement_timeout = 1000")))
+	db.SetMaxOpenConns(1)
+	conn := &Connection{DB: db, Dialect: dialect.PostgreSQL, EventReceiver: nullReceiver}
+	sess := conn.NewSession(nil)
+
+	for i := 0; i < 2; i++ {
+		_, err := sess.Update("t").Set("a", 1).Exec()
+		assert.NoError(t, err)
+	}
+	stmts := fake.statements()
+	if assert.Len(t, stmts, 4) {
+		// once per connection before it is used
+		assert.Equal(t, "SET TIME ZONE 'UTC'", stmts[0].query)
+		assert.Equal(t, "SET statement_timeout = 1000", stmts[1].query)
+		assert.Equal(t, `UPDATE "t" SET "a" = 1`, stmts[2].query)
+		assert.Equal(t, `UPDATE "t" SET "a" = 1`, stmts[3].query)
+	}
+
+	failed := errors.New("permission denied")
+	db = sql.OpenDB(InitConnector(fake, func(context.Context, driver.Conn) error {
+		return failed
+	}))
+	_, err := db.Exec("SELECT 1")
+	assert.Equal(t, failed, err)
+}
+
+func TestOpenWithInit(t *testing.T) {
+	conn, err := OpenWithInit("sqlite3", ":memory:", nil, ExecInit("PRAGMA case_sensitive_like = ON"))
+	assert.NoError(t, err)
+	defer conn.Close()
+
+	// LIKE is case-insensitive by default
+	var match bool
+	err = conn.NewSession(nil).SelectBySql("SELECT 'A' LIKE 'a'").LoadValue(&match)
+	assert.NoError(t, err)
+	assert.False(t, match)
+
+	_, err = OpenWithInit("unknown", "", nil)
+	assert.Error(t, err)
+}
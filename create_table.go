@@ -0,0 +1,75 @@
+package dbr
+
+// CreateTableStmt builds `CREATE TABLE ... AS SELECT ...`, or `SELECT ... INTO ...` in dialects
+// implementing SelectIntoDialect
+type CreateTableStmt interface {
+	Builder
+	Temporary() CreateTableStmt
+}
+
+type createTableStmt struct {
+	Table       string
+	IsTemporary bool
+	Query       Builder
+}
+
+// Build builds `CREATE TABLE ... AS SELECT ...` in dialect
+func (b *createTableStmt) Build(d Dialect, buf Buffer) error {
+	if b.Table == "" {
+		return ErrTableNotSpecified
+	}
+
+	if s, ok := baseDialect(d).(SelectIntoDialect); ok {
+		if stmt := selectOf(b.Query); stmt != nil {
+			into := *stmt
+			into.Into = s.SelectInto(b.Table, b.IsTemporary)
+			if into.Into == "" {
+				return ErrNotSupported
+			}
+			return into.Build(d, buf)
+		}
+	}
+
+	c, ok := baseDialect(d).(CreateTableAsDialect)
+	if !ok {
+		return ErrNotSupported
+	}
+	keyword := c.CreateTableAs(b.Table, b.IsTemporary)
+	if len(keyword) == 0 {
+		return ErrNotSupported
+	}
+
+	buf.WriteString(keyword)
+	buf.WriteString(" ")
+	// not a placeholder, subquery would be parenthesized
+	return b.Query.Build(d, buf)
+}
+
+// selectOf returns the statement of query built with Select, or nil
+func selectOf(query Builder) *selectStmt {
+	switch query := query.(type) {
+	case *selectStmt:
+		return query
+	case *selectBuilder:
+		return query.selectStmt
+	}
+	return nil
+}
+
+// CreateTableAs creates a CreateTableStmt, which materializes result of query in a new table
+func CreateTableAs(table string, query Builder) CreateTableStmt {
+	return createCreateTableStmt(table, query)
+}
+
+func createCreateTableStmt(table string, query Builder) *createTableStmt {
+	return &createTableStmt{
+		Table: table,
+		Query: query,
+	}
+}
+
+// Temporary creates a temporary table
+func (b *createTableStmt) Temporary() CreateTableStmt {
+	b.IsTemporary = true
+	return b
+}
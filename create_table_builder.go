@@ -0,0 +1,56 @@
+package dbr
+
+import "database/sql"
+
+// CreateTableBuilder builds `CREATE TABLE ... AS SELECT ...`
+type CreateTableBuilder interface {
+	Builder
+	EventReceiver
+	Executer
+
+	Temporary() CreateTableBuilder
+}
+
+type createTableBuilder struct {
+	runner
+	EventReceiver
+
+	Dialect         Dialect
+	createTableStmt *createTableStmt
+}
+
+// CreateTableAs creates a CreateTableBuilder
+func (sess *Session) CreateTableAs(table string, query Builder) CreateTableBuilder {
+	return &createTableBuilder{
+		runner:          sess,
+		EventReceiver:   sess,
+		Dialect:         sess.Dialect,
+		createTableStmt: createCreateTableStmt(table, query),
+	}
+}
+
+// CreateTableAs creates a CreateTableBuilder
+func (tx *Tx) CreateTableAs(table string, query Builder) CreateTableBuilder {
+	return &createTableBuilder{
+		runner:          tx,
+		EventReceiver:   tx,
+		Dialect:         tx.Dialect,
+		createTableStmt: createCreateTableStmt(table, query),
+	}
+}
+
+// Exec executes the stmt
+func (b *createTableBuilder) Exec() (sql.Result, error) {
+	return exec(b.runner, b.EventReceiver, b, b.Dialect)
+}
+
+// Temporary creates a temporary table
+func (b *createTableBuilder) Temporary() CreateTableBuilder {
+	b.createTableStmt.Temporary()
+	return b
+}
+
+// Build builds `CREATE TABLE ... AS SELECT ...` in dialect
+func (b *createTableBuilder) Build(d Dialect, buf Buffer) error {
+	return b.createTableStmt.Build(b.Dialect, buf)
+}
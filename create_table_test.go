@@ -0,0 +1,103 @@
+package dbr
+
+import (
+	"testing"
+
+	"github.com/lianchengwu/dbr/dialect"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateTableAsStmt(t *testing.T) {
+	query := Select("id", "name").From("dbr_people").Where(Gt("id", 10))
+	for _, test := range []struct {
+		dialect   Dialect
+		temporary bool
+		query     string
+	}{
+		{
+			dialect: dialect.PostgreSQL,
+			query:   `CREATE TABLE "staging" AS SELECT id, name FROM dbr_people WHERE ("id" > ?)`,
+		},
+		{
+			dialect:   dialect.PostgreSQL,
+			temporary: true,
+			query:     `CREATE TEMPORARY TABLE "staging" AS SELECT id, name FROM dbr_people WHERE ("id" > ?)`,
+		},
+		{
+			dialect: dialect.MySQL,
+			query:   "CREATE TABLE `staging` AS SELECT id, name FROM dbr_people WHERE (`id` > ?)",
+		},
+	} {
+		buf := NewBuffer()
+		builder := CreateTableAs("staging", query)
+		if test.temporary {
+			builder.Temporary()
+		}
+		err := builder.Build(test.dialect, buf)
+		assert.NoError(t, err)
+		assert.Equal(t, test.query, buf.String())
+		assert.Equal(t, []interface{}{10}, buf.Value())
+	}
+
+	err := CreateTableAs("staging", query).Build(dialect.ClickHouse, NewBuffer())
+	assert.Equal(t, ErrNotSupported, err)
+
+	// SELECT ... INTO
+	d := selectIntoDialect{dialect.PostgreSQL}
+	buf := NewBuffer()
+	err = CreateTableAs("staging", query).Build(d, buf)
+	assert.NoError(t, err)
+	assert.Equal(t, `SELECT id, name INTO "staging" FROM dbr_people WHERE ("id" > ?)`, buf.String())
+	assert.Equal(t, []interface{}{10}, buf.Value())
+
+	sess, _ := newFakeSession(d)
+	buf = NewBuffer()
+	err = CreateTableAs("staging", sess.Select("id").From("dbr_people")).Temporary().Build(d, buf)
+	assert.NoError(t, err)
+	assert.Equal(t, `SELECT id INTO "#staging" FROM dbr_people`, buf.String())
+
+	// other queries use CREATE TABLE AS
+	buf = NewBuffer()
+	err = CreateTableAs("staging", Expr("VALUES (1)")).Build(d, buf)
+	assert.NoError(t, err)
+	assert.Equal(t, `CREATE TABLE "staging" AS VALUES (1)`, buf.String())
+}
+
+// selectIntoDialect creates tables with SELECT ... INTO like SQL Server
+type selectIntoDialect struct {
+	fullDialect
+}
+
+func (d selectIntoDialect) SelectInto(table string, temporary bool) string {
+	if temporary {
+		table = "#" + table
+	}
+	return "INTO " + d.QuoteIdent(table)
+}
+
+func TestCreateTableAs(t *testing.T) {
+	for _, sess := range testSession {
+		if sess.Dialect == dialect.ClickHouse {
+			continue
+		}
+		id := nextID()
+		_, err := sess.InsertInto("dbr_people").Columns("id", "name", "email").Values(id, "staged", "staged@example.com").Exec()
+		assert.NoError(t, err)
+
+		_, err = sess.Exec("DROP TABLE IF EXISTS dbr_people_staging")
+		assert.NoError(t, err)
+		_, err = sess.CreateTableAs("dbr_people_staging", Select("id", "name").From("dbr_people").Where(Eq("id", id))).Exec()
+		assert.NoError(t, err)
+		// other tests expect dbr_people to be empty
+		_, err = sess.DeleteFrom("dbr_people").Where(Eq("id", id)).Exec()
+		assert.NoError(t, err)
+
+		var people []person
+		count, err := sess.Select("*").From("dbr_people_staging").LoadStructs(&people)
+		assert.NoError(t, err)
+		if assert.Equal(t, 1, count) {
+			assert.Equal(t, id, people[0].ID)
+			assert.Equal(t, "staged", people[0].Name)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package dbr
+
+import "reflect"
+
+// Crypter encrypts and decrypts the values of struct fields tagged with crypt,
+// e.g. `db:"ssn,crypt"`, see Session.Crypter.
+// Fields must be a string or []byte. The ciphertext of string fields is stored as a string,
+// so it should be encoded (e.g. with base64) if the column is not binary.
+type Crypter interface {
+	Encrypt(column string, plaintext []byte) ([]byte, error)
+	Decrypt(column string, ciphertext []byte) ([]byte, error)
+}
+
+// cryptValue is a value of a crypt field added by Record or SetRecord,
+// it is encrypted with the Crypter of the session on interpolation.
+type cryptValue struct {
+	column string
+	value  interface{}
+}
+
+// recordValue returns the value of field for column, it is wrapped to be encrypted if it is a crypt field
+func recordValue(column string, field reflect.Value, crypt map[string]bool) interface{} {
+	if crypt[column] {
+		return cryptValue{column: column, value: field.Interface()}
+	}
+	return field.Interface()
+}
+
+func (i *interpolator) encrypt(v cryptValue) (interface{}, error) {
+	if i.Crypter == nil {
+		return nil, ErrCrypterNotSpecified
+	}
+	switch value := v.value.(type) {
+	case string:
+		b, err := i.Crypter.Encrypt(v.column, []byte(value))
+		if err != nil {
+			return nil, err
+		}
+		return string(b), nil
+	case []byte:
+		if value == nil {
+			return nil, nil
+		}
+		return i.Crypter.Encrypt(v.column, value)
+	}
+	return nil, ErrInvalidCryptField
+}
+
+// cryptScanner decrypts a value into field, NULL is loaded as the zero value
+type cryptScanner struct {
+	column  string
+	field   reflect.Value
+	crypter Crypter
+}
+
+func (s cryptScanner) Scan(v interface{}) error {
+	if v == nil {
+		s.field.Set(reflect.Zero(s.field.Type()))
+		return nil
+	}
+	var ciphertext []byte
+	switch v := v.(type) {
+	case []byte:
+		ciphertext = v
+	case string:
+		ciphertext = []byte(v)
+	default:
+		return ErrInvalidCryptField
+	}
+	b, err := s.crypter.Decrypt(s.column, ciphertext)
+	if err != nil {
+		return err
+	}
+	switch s.field.Kind() {
+	case reflect.String:
+		s.field.SetString(string(b))
+	default:
+		s.field.SetBytes(append([]byte(nil), b...))
+	}
+	return nil
+}
+
+// cryptColumns returns the columns of crypt fields if t is a struct
+func cryptColumns(t reflect.Type) map[string]bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || reflect.PtrTo(t).Implements(typeScanner) {
+		return nil
+	}
+	_, crypt := structFields(t)
+	return crypt
+}
+
+// cryptDest wraps the pointers of crypt fields to decrypt them, see Session.Crypter
+func cryptDest(column []string, ptr []interface{}, crypt map[string]bool, crypter Crypter) error {
+	for i, col := range column {
+		if !crypt[col] {
+			continue
+		}
+		if crypter == nil {
+			return ErrCrypterNotSpecified
+		}
+		field := reflect.ValueOf(ptr[i]).Elem()
+		if !isCryptKind(field.Type()) {
+			return ErrInvalidCryptField
+		}
+		ptr[i] = cryptScanner{column: col, field: field, crypter: crypter}
+	}
+	return nil
+}
+
+func isCryptKind(t reflect.Type) bool {
+	return t.Kind() == reflect.String || t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8
+}
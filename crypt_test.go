@@ -0,0 +1,98 @@
+package dbr
+
+import (
+	"encoding/base64"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/lianchengwu/dbr/dialect"
+	"github.com/stretchr/testify/assert"
+)
+
+// base64Crypter is a fake Crypter which only encodes the values
+type base64Crypter struct{}
+
+func (base64Crypter) Encrypt(column string, plaintext []byte) ([]byte, error) {
+	return []byte(column + ":" + base64.StdEncoding.EncodeToString(plaintext)), nil
+}
+
+func (base64Crypter) Decrypt(column string, ciphertext []byte) ([]byte, error) {
+	prefix := column + ":"
+	if len(ciphertext) < len(prefix) || string(ciphertext[:len(prefix)]) != prefix {
+		return nil, fmt.Errorf("invalid ciphertext %q", ciphertext)
+	}
+	return base64.StdEncoding.DecodeString(string(ciphertext[len(prefix):]))
+}
+
+type cryptKey struct {
+	KeyValue string `db:"key_value"`
+	ValValue string `db:"val_value,crypt"`
+}
+
+func TestStructFieldsCrypt(t *testing.T) {
+	type person struct {
+		Name   string `db:"name"`
+		SSN    string `db:"ssn,crypt"`
+		Secret []byte `db:",crypt"`
+	}
+	m, crypt := structFields(reflect.TypeOf(person{}))
+	assert.Equal(t, map[string][]int{"name": {0}, "ssn": {1}, "secret": {2}}, m)
+	assert.Equal(t, map[string]bool{"ssn": true, "secret": true}, crypt)
+}
+
+func TestCrypter(t *testing.T) {
+	for _, sess := range testSession {
+		key := fmt.Sprintf("crypt_%d", nextID())
+		_, err := sess.InsertInto("dbr_keys").Record(&cryptKey{KeyValue: key, ValValue: "secret"}).Exec()
+		assert.Equal(t, ErrCrypterNotSpecified, err)
+
+		sess.Crypter = base64Crypter{}
+		_, err = sess.InsertInto("dbr_keys").Record(&cryptKey{KeyValue: key, ValValue: "secret"}).Exec()
+		assert.NoError(t, err)
+
+		// the ciphertext is stored
+		var stored string
+		err = sess.Select("val_value").From("dbr_keys").Where(Eq("key_value", key)).LoadValue(&stored)
+		assert.NoError(t, err)
+		assert.Equal(t, "val_value:c2VjcmV0", stored)
+
+		var loaded cryptKey
+		err = sess.Select("*").From("dbr_keys").Where(Eq("key_value", key)).LoadStruct(&loaded)
+		assert.NoError(t, err)
+		assert.Equal(t, cryptKey{KeyValue: key, ValValue: "secret"}, loaded)
+
+		_, err = sess.Update("dbr_keys").SetRecord(&cryptKey{KeyValue: key, ValValue: "changed"}).
+			Where(Eq("key_value", key)).Exec()
+		assert.NoError(t, err)
+
+		var all []cryptKey
+		_, err = sess.Select("*").From("dbr_keys").Where(Eq("key_value", key)).Load(&all)
+		assert.NoError(t, err)
+		assert.Equal(t, []cryptKey{{KeyValue: key, ValValue: "changed"}}, all)
+
+		sess.Crypter = nil
+		err = sess.Select("*").From("dbr_keys").Where(Eq("key_value", key)).LoadStruct(&loaded)
+		assert.Equal(t, ErrCrypterNotSpecified, err)
+	}
+}
+
+func TestCrypterBind(t *testing.T) {
+	sess, fake := newFakeSession(dialect.PostgreSQL)
+	sess.DisableInterpolation = true
+	sess.Crypter = base64Crypter{}
+	_, err := sess.InsertInto("people").Record(&struct {
+		Name  string
+		Token []byte `db:"token,crypt"`
+	}{Name: "a", Token: []byte("t")}).Exec()
+	assert.NoError(t, err)
+	stmts := fake.statements()
+	assert.Len(t, stmts, 1)
+	assert.Equal(t, `INSERT INTO "people" ("name","token") VALUES ($1,$2)`, stmts[0].query)
+	assert.Equal(t, []interface{}{"a", []byte("token:dA==")}, stmts[0].args)
+
+	_, err = sess.InsertInto("people").Record(&struct {
+		Count int `db:"count,crypt"`
+	}{Count: 1}).Exec()
+	assert.Equal(t, ErrInvalidCryptField, err)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/lianchengwu/dbr/dialect"
@@ -19,6 +20,15 @@ func Open(driver, dsn string, log EventReceiver) (*Connection, error) {
 	if err != nil {
 		return nil, err
 	}
+	d, err := driverDialect(driver)
+	if err != nil {
+		return nil, err
+	}
+	return &Connection{DB: conn, EventReceiver: log, Dialect: d}, nil
+}
+
+// driverDialect returns the dialect of a database/sql driver name
+func driverDialect(driver string) (Dialect, error) {
 	var d Dialect
 	switch driver {
 	case "mysql":
@@ -29,10 +39,12 @@ func Open(driver, dsn string, log EventReceiver) (*Connection, error) {
 		d = dialect.SQLite3
 	case "clickhouse":
 		d = dialect.ClickHouse
+	case "godror", "oci8":
+		d = dialect.Oracle
 	default:
 		return nil, ErrNotSupported
 	}
-	return &Connection{DB: conn, EventReceiver: log, Dialect: d}, nil
+	return d, nil
 }
 
 const (
@@ -45,6 +57,9 @@ type Connection struct {
 	*sql.DB
 	Dialect Dialect
 	EventReceiver
+
+	reportMu  sync.Mutex
+	reporters []*statsReporter
 }
 
 // Session represents a business unit of execution for some connection
@@ -52,6 +67,52 @@ type Session struct {
 	*Connection
 	EventReceiver
 	ctx context.Context
+
+	// TxTimeout enables long transaction detection when it is greater than zero.
+	// A transaction that is still open after TxTimeout sends "dbr.tx.timeout" event.
+	TxTimeout time.Duration
+	// TxTimeoutRollback rolls back a transaction which exceeded TxTimeout
+	TxTimeoutRollback bool
+	// DisableInterpolation sends values to the driver as bound parameters instead of
+	// interpolating them into the query. Values are passed unmodified, so the driver
+	// (e.g. via driver.NamedValueChecker) is responsible for their encoding.
+	// Builders and slices or maps which are not driver.Valuer (e.g. for IN) are still expanded.
+	DisableInterpolation bool
+	// StrictInterpolation rejects interpolated strings containing NUL bytes,
+	// which some databases truncate silently, with ErrNulByte.
+	StrictInterpolation bool
+	// MaxValueSize rejects interpolated string and []byte values longer than
+	// MaxValueSize bytes with ErrValueTooLarge when it is greater than zero.
+	MaxValueSize int
+	// IgnoreUnknownColumns ignores the columns which do not match a struct field when loading into structs,
+	// so one struct can be loaded by queries of different columns. By default such columns fail with ErrColumnMismatch.
+	// Fields without a column are left unchanged in both modes.
+	IgnoreUnknownColumns bool
+	// NullAsZero loads NULL into values which can not hold it (e.g. int or string fields of structs)
+	// as their zero value instead of failing, e.g. for columns of LEFT JOINs.
+	// Pointers, sql.Scanner (e.g. dbr.NullString) and interface{} values still get NULL.
+	NullAsZero bool
+	// Crypter encrypts the values of struct fields tagged with crypt, e.g. `db:"ssn,crypt"`,
+	// when they are added by InsertStmt.Record or UpdateStmt.SetRecord, and decrypts them when they are loaded.
+	// Without it such fields fail with ErrCrypterNotSpecified.
+	Crypter Crypter
+	// TableResolver resolves the physical tables of sharded tables, see Shard, SelectStmt.FromShard
+	// and Shard of InsertStmt, UpdateStmt and DeleteStmt. The rest of the query is unchanged.
+	TableResolver TableResolver
+	// RequireWhere makes UPDATE and DELETE builders fail with ErrMissingWhere
+	// if they have no WHERE condition, or only conditions which are always true.
+	// Call AllRows to update or delete all rows.
+	RequireWhere bool
+	// DurationUnit makes time.Duration values interpolated as the integer number of DurationUnit,
+	// e.g. time.Second for seconds, when it is greater than zero.
+	// Otherwise durations are interpolated as an interval (INTERVAL '... microseconds' in PostgreSQL)
+	// in dialects having interval literals, and as nanoseconds in others.
+	DurationUnit time.Duration
+	// Format is the style of the queries sent to the database and events, e.g. lowercase keywords
+	// and clauses on separate lines for legible logs. By default queries are sent as they are built.
+	// It only changes the queries executed and logged by the session, queries built otherwise
+	// (e.g. with Build or InterpolateForDialect) are unchanged, apply Format.Apply to them for the same style.
+	Format Format
 }
 
 // NewSession instantiates a Session for the Connection
@@ -72,7 +133,21 @@ func (sess *Session) NewSession(log EventReceiver) *Session {
 	if log == nil {
 		log = sess.EventReceiver
 	}
-	return &Session{Connection: sess.Connection, EventReceiver: log, ctx: sess.ctx}
+	fork := *sess
+	fork.EventReceiver = log
+	return &fork
+}
+
+func (sess *Session) getContext() context.Context {
+	return sess.ctx
+}
+
+func (sess *Session) getSession() *Session {
+	return sess
+}
+
+func (sess *Session) getTx() *Tx {
+	return nil
 }
 
 // beginTx starts a transaction with context.
@@ -95,9 +170,22 @@ type SessionRunner interface {
 	DeleteBySql(query string, value ...interface{}) DeleteBuilder
 }
 
+// ExtendedRunner is a SessionRunner with the builders added after it.
+// They are not a part of SessionRunner, so its implementations (e.g. mocks) keep compiling.
+type ExtendedRunner interface {
+	SessionRunner
+
+	Tree(table, id, parentID string, root interface{}, maxDepth int) SelectBuilder
+	CreateTableAs(table string, query Builder) CreateTableBuilder
+}
+
 type runner interface {
 	Exec(query string, args ...interface{}) (sql.Result, error)
 	Query(query string, args ...interface{}) (*sql.Rows, error)
+	getContext() context.Context
+	getSession() *Session
+	// getTx returns the transaction of the runner, or nil
+	getTx() *Tx
 }
 
 // Executer can execute requests to database
@@ -111,72 +199,135 @@ type loader interface {
 	LoadStructs(value interface{}) (int, error)
 	LoadValue(value interface{}) error
 	LoadValues(value interface{}) (int, error)
+	Scan(dest ...interface{}) error
 }
 
-func exec(runner runner, log EventReceiver, builder Builder, d Dialect) (sql.Result, error) {
-	i := interpolator{
+// newInterpolator creates an interpolator for queries of sess
+func newInterpolator(sess *Session, d Dialect) *interpolator {
+	if sess.TableResolver != nil {
+		d = resolverDialect{Dialect: d, resolver: sess.TableResolver}
+	}
+	return &interpolator{
 		Buffer:       NewBuffer(),
 		Dialect:      d,
 		IgnoreBinary: true,
+		Bind:         sess.DisableInterpolation,
+		Strict:       sess.StrictInterpolation,
+		MaxValueSize: sess.MaxValueSize,
+		DurationUnit: sess.DurationUnit,
+		Crypter:      sess.Crypter,
 	}
+}
+
+func exec(runner runner, log EventReceiver, builder Builder, d Dialect) (sql.Result, error) {
+	i := newInterpolator(runner.getSession(), d)
+	ctx := runner.getContext()
 	err := i.interpolate(placeholder, []interface{}{builder})
-	query, value := i.String(), i.Value()
+	query, value := runner.getSession().Format.Apply(d, i.String()), i.Value()
 	if err != nil {
-		return nil, log.EventErrKv("dbr.exec.interpolate", err, kvs{
+		return nil, eventErr(log, d, "dbr.exec.interpolate", err, eventKvs(ctx, kvs{
 			"sql":  query,
 			"args": fmt.Sprint(value),
-		})
+		}))
 	}
 
 	startTime := time.Now()
 	defer func() {
-		log.TimingKv("dbr.exec", time.Since(startTime).Nanoseconds(), kvs{
+		log.TimingKv("dbr.exec", time.Since(startTime).Nanoseconds(), eventKvs(ctx, kvs{
 			"sql": query,
-		})
+		}))
 	}()
 
 	result, err := runner.Exec(query, value...)
 	if err != nil {
-		return result, log.EventErrKv("dbr.exec.exec", err, kvs{
+		return result, eventErr(log, d, "dbr.exec.exec", err, eventKvs(ctx, kvs{
 			"sql": query,
-		})
+		}))
 	}
 	return result, nil
 }
 
+// eventErr sends err to log, and its class if log is an ErrorClassReceiver
+func eventErr(log EventReceiver, d Dialect, eventName string, err error, m kvs) error {
+	if r, ok := errorClassReceiver(log); ok {
+		r.EventErrClass(eventName, ClassifyError(d, err), err, m)
+	}
+	return log.EventErrKv(eventName, err, m)
+}
+
 func query(runner runner, log EventReceiver, builder Builder, d Dialect, dest interface{}) (int, error) {
-	i := interpolator{
-		Buffer:       NewBuffer(),
-		Dialect:      d,
-		IgnoreBinary: true,
+	var count int
+	err := queryRows(runner, log, builder, d, func(rows *sql.Rows) error {
+		var err error
+		count, err = load(rows, dest, sessionLoadOptions(runner.getSession()))
+		return err
+	})
+	if err != nil {
+		return 0, err
 	}
+	return count, nil
+}
+
+// scan loads the first row into dest positionally, returns ErrNotFound if there is no result
+func scan(runner runner, log EventReceiver, builder Builder, d Dialect, dest []interface{}) error {
+	found := false
+	err := queryRows(runner, log, builder, d, func(rows *sql.Rows) error {
+		defer rows.Close()
+		column, err := rows.Columns()
+		if err != nil {
+			return err
+		}
+		if len(column) != len(dest) {
+			return ErrDestinationCount
+		}
+		if rows.Next() {
+			found = true
+			err = rows.Scan(dest...)
+			if err != nil {
+				return err
+			}
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return err
+	}
+	if !found {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func queryRows(runner runner, log EventReceiver, builder Builder, d Dialect, load func(*sql.Rows) error) error {
+	i := newInterpolator(runner.getSession(), d)
+	ctx := runner.getContext()
 	err := i.interpolate(placeholder, []interface{}{builder})
-	query, value := i.String(), i.Value()
+	query, value := runner.getSession().Format.Apply(d, i.String()), i.Value()
 	if err != nil {
-		return 0, log.EventErrKv("dbr.select.interpolate", err, kvs{
+		return eventErr(log, d, "dbr.select.interpolate", err, eventKvs(ctx, kvs{
 			"sql":  query,
 			"args": fmt.Sprint(value),
-		})
+		}))
 	}
 
 	startTime := time.Now()
 	defer func() {
-		log.TimingKv("dbr.select", time.Since(startTime).Nanoseconds(), kvs{
+		log.TimingKv("dbr.select", time.Since(startTime).Nanoseconds(), eventKvs(ctx, kvs{
 			"sql": query,
-		})
+		}))
 	}()
 
 	rows, err := runner.Query(query, value...)
 	if err != nil {
-		return 0, log.EventErrKv("dbr.select.load.query", err, kvs{
+		return eventErr(log, d, "dbr.select.load.query", err, eventKvs(ctx, kvs{
 			"sql": query,
-		})
+		}))
 	}
-	count, err := Load(rows, dest)
+	err = load(rows)
 	if err != nil {
-		return 0, log.EventErrKv("dbr.select.load.scan", err, kvs{
+		return eventErr(log, d, "dbr.select.load.scan", err, eventKvs(ctx, kvs{
 			"sql": query,
-		})
+		}))
 	}
-	return count, nil
+	return nil
 }
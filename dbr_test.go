@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"log"
 	"os"
+	"sync"
 	"testing"
 
 	"github.com/lianchengwu/dbr/dialect"
@@ -17,8 +18,10 @@ import (
 
 // Ensure that tx and session are session runner
 var (
-	_ SessionRunner = (*Tx)(nil)
-	_ SessionRunner = (*Session)(nil)
+	_ SessionRunner  = (*Tx)(nil)
+	_ SessionRunner  = (*Session)(nil)
+	_ ExtendedRunner = (*Tx)(nil)
+	_ ExtendedRunner = (*Session)(nil)
 )
 
 var (
@@ -88,6 +91,60 @@ type nullTypedRecord struct {
 	BoolVal    NullBool
 }
 
+type testEvent struct {
+	name string
+	err  error
+	kvs  map[string]string
+}
+
+// testEventReceiver records received events, it is safe for concurrent use
+type testEventReceiver struct {
+	NullEventReceiver
+
+	mu     sync.Mutex
+	events []testEvent
+}
+
+func (r *testEventReceiver) record(e testEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, e)
+}
+
+func (r *testEventReceiver) Event(eventName string) {
+	r.record(testEvent{name: eventName})
+}
+
+func (r *testEventReceiver) EventKv(eventName string, kvs map[string]string) {
+	r.record(testEvent{name: eventName, kvs: kvs})
+}
+
+func (r *testEventReceiver) EventErr(eventName string, err error) error {
+	r.record(testEvent{name: eventName, err: err})
+	return err
+}
+
+func (r *testEventReceiver) EventErrKv(eventName string, err error, kvs map[string]string) error {
+	r.record(testEvent{name: eventName, err: err, kvs: kvs})
+	return err
+}
+
+func (r *testEventReceiver) TimingKv(eventName string, nanoseconds int64, kvs map[string]string) {
+	r.record(testEvent{name: eventName, kvs: kvs})
+}
+
+// find returns the last received event with the name
+func (r *testEventReceiver) find(eventName string) (testEvent, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i := len(r.events) - 1; i >= 0; i-- {
+		if r.events[i].name == eventName {
+			return r.events[i], true
+		}
+	}
+	return testEvent{}, false
+}
+
 func reset(sess *Session) {
 	var stmts []string
 	switch sess.Dialect {
@@ -0,0 +1,56 @@
+// Package dbrtest records the queries of a connection with their results and replays them
+// without a database, so code using dbr can be tested deterministically.
+//
+// Queries are recorded at the driver level, so they have the rendered SQL and args
+// as the database gets them:
+//
+//	rec := dbrtest.NewRecorder(&sqlite3.SQLiteDriver{}, "test.db")
+//	conn := &dbr.Connection{DB: sql.OpenDB(rec), Dialect: dialect.SQLite3, EventReceiver: &dbr.NullEventReceiver{}}
+//	run(conn.NewSession(nil))
+//	dbrtest.WriteQueries(f, rec.Queries())
+//
+// and replayed in the same order:
+//
+//	queries, _ := dbrtest.ReadQueries(f)
+//	conn, replay := dbrtest.Open(dialect.SQLite3, queries)
+//	run(conn.NewSession(nil))
+//	if err := replay.ExpectationsWereMet(); err != nil {
+//		t.Fatal(err)
+//	}
+package dbrtest
+
+import (
+	"encoding/gob"
+	"io"
+	"time"
+)
+
+func init() {
+	gob.Register(time.Time{})
+}
+
+// Query is a recorded query with its result
+type Query struct {
+	SQL  string
+	Args []interface{}
+	// Columns and Rows are the result of a query, only rows read by the caller are recorded
+	Columns []string
+	Rows    [][]interface{}
+	// LastInsertID and RowsAffected are the result of an exec
+	LastInsertID int64
+	RowsAffected int64
+	// Err is the error message of a failed query
+	Err string
+}
+
+// WriteQueries writes queries to w with encoding/gob
+func WriteQueries(w io.Writer, queries []Query) error {
+	return gob.NewEncoder(w).Encode(queries)
+}
+
+// ReadQueries reads queries written by WriteQueries
+func ReadQueries(r io.Reader) ([]Query, error) {
+	var queries []Query
+	err := gob.NewDecoder(r).Decode(&queries)
+	return queries, err
+}
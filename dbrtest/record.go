@@ -0,0 +1,263 @@
+package dbrtest
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"sync"
+)
+
+// Recorder is a driver.Connector recording the queries of its connections, use it with sql.OpenDB
+type Recorder struct {
+	connector driver.Connector
+	mu        sync.Mutex
+	queries   []*Query
+}
+
+type dsnConnector struct {
+	dsn string
+	drv driver.Driver
+}
+
+func (c *dsnConnector) Connect(context.Context) (driver.Conn, error) {
+	return c.drv.Open(c.dsn)
+}
+
+func (c *dsnConnector) Driver() driver.Driver {
+	return c.drv
+}
+
+// NewRecorder returns a Recorder connecting to dsn with drv
+func NewRecorder(drv driver.Driver, dsn string) *Recorder {
+	var c driver.Connector = &dsnConnector{dsn: dsn, drv: drv}
+	if dc, ok := drv.(driver.DriverContext); ok {
+		if connector, err := dc.OpenConnector(dsn); err == nil {
+			c = connector
+		}
+	}
+	return &Recorder{connector: c}
+}
+
+// Connect implements driver.Connector
+func (r *Recorder) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := r.connector.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &recordConn{conn: conn, r: r}, nil
+}
+
+// Driver implements driver.Connector
+func (r *Recorder) Driver() driver.Driver {
+	return r.connector.Driver()
+}
+
+// Queries returns the recorded queries in the order they ran
+func (r *Recorder) Queries() []Query {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	queries := make([]Query, len(r.queries))
+	for i, q := range r.queries {
+		queries[i] = *q
+	}
+	return queries
+}
+
+func (r *Recorder) record(query string, args []driver.NamedValue) *Query {
+	q := &Query{SQL: query}
+	for _, arg := range args {
+		q.Args = append(q.Args, copyValue(arg.Value))
+	}
+	r.mu.Lock()
+	r.queries = append(r.queries, q)
+	r.mu.Unlock()
+	return q
+}
+
+func (r *Recorder) recordExec(q *Query, result driver.Result, err error) (driver.Result, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err != nil {
+		q.Err = err.Error()
+		return nil, err
+	}
+	// drivers may not support both
+	q.LastInsertID, _ = result.LastInsertId()
+	q.RowsAffected, _ = result.RowsAffected()
+	return result, nil
+}
+
+func (r *Recorder) recordQuery(q *Query, rows driver.Rows, err error) (driver.Rows, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err != nil {
+		q.Err = err.Error()
+		return nil, err
+	}
+	q.Columns = rows.Columns()
+	return &recordRows{Rows: rows, q: q, r: r}, nil
+}
+
+// copyValue copies []byte which is only valid until the next call of the driver
+func copyValue(v interface{}) interface{} {
+	if b, ok := v.([]byte); ok {
+		return append([]byte(nil), b...)
+	}
+	return v
+}
+
+type recordConn struct {
+	conn driver.Conn
+	r    *Recorder
+}
+
+func (c *recordConn) Prepare(query string) (driver.Stmt, error) {
+	stmt, err := c.conn.Prepare(query)
+	if err != nil {
+		return nil, err
+	}
+	return &recordStmt{stmt: stmt, query: query, r: c.r}, nil
+}
+
+func (c *recordConn) Close() error {
+	return c.conn.Close()
+}
+
+func (c *recordConn) Begin() (driver.Tx, error) {
+	return c.conn.Begin()
+}
+
+func (c *recordConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if b, ok := c.conn.(driver.ConnBeginTx); ok {
+		return b.BeginTx(ctx, opts)
+	}
+	return c.conn.Begin()
+}
+
+func (c *recordConn) CheckNamedValue(v *driver.NamedValue) error {
+	if checker, ok := c.conn.(driver.NamedValueChecker); ok {
+		return checker.CheckNamedValue(v)
+	}
+	return driver.ErrSkip
+}
+
+func (c *recordConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	execer, ok := c.conn.(driver.ExecerContext)
+	if !ok {
+		// database/sql falls back to Prepare
+		return nil, driver.ErrSkip
+	}
+	result, err := execer.ExecContext(ctx, query, args)
+	if err == driver.ErrSkip {
+		return nil, err
+	}
+	return c.r.recordExec(c.r.record(query, args), result, err)
+}
+
+func (c *recordConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	queryer, ok := c.conn.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	rows, err := queryer.QueryContext(ctx, query, args)
+	if err == driver.ErrSkip {
+		return nil, err
+	}
+	return c.r.recordQuery(c.r.record(query, args), rows, err)
+}
+
+type recordStmt struct {
+	stmt  driver.Stmt
+	query string
+	r     *Recorder
+}
+
+func (s *recordStmt) Close() error {
+	return s.stmt.Close()
+}
+
+func (s *recordStmt) NumInput() int {
+	return s.stmt.NumInput()
+}
+
+func (s *recordStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return s.ExecContext(context.Background(), namedValues(args))
+}
+
+func (s *recordStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return s.QueryContext(context.Background(), namedValues(args))
+}
+
+func (s *recordStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	q := s.r.record(s.query, args)
+	if execer, ok := s.stmt.(driver.StmtExecContext); ok {
+		result, err := execer.ExecContext(ctx, args)
+		return s.r.recordExec(q, result, err)
+	}
+	v, err := values(args)
+	if err != nil {
+		return s.r.recordExec(q, nil, err)
+	}
+	result, err := s.stmt.Exec(v)
+	return s.r.recordExec(q, result, err)
+}
+
+func (s *recordStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	q := s.r.record(s.query, args)
+	if queryer, ok := s.stmt.(driver.StmtQueryContext); ok {
+		rows, err := queryer.QueryContext(ctx, args)
+		return s.r.recordQuery(q, rows, err)
+	}
+	v, err := values(args)
+	if err != nil {
+		return s.r.recordQuery(q, nil, err)
+	}
+	rows, err := s.stmt.Query(v)
+	return s.r.recordQuery(q, rows, err)
+}
+
+func namedValues(args []driver.Value) []driver.NamedValue {
+	named := make([]driver.NamedValue, len(args))
+	for i, v := range args {
+		named[i] = driver.NamedValue{Ordinal: i + 1, Value: v}
+	}
+	return named
+}
+
+func values(args []driver.NamedValue) ([]driver.Value, error) {
+	v := make([]driver.Value, len(args))
+	for i, arg := range args {
+		if arg.Name != "" {
+			return nil, errors.New("dbrtest: driver does not support named args")
+		}
+		v[i] = arg.Value
+	}
+	return v, nil
+}
+
+// recordRows records the rows read by the caller
+type recordRows struct {
+	driver.Rows
+	q *Query
+	r *Recorder
+}
+
+func (rows *recordRows) Next(dest []driver.Value) error {
+	err := rows.Rows.Next(dest)
+	rows.r.mu.Lock()
+	defer rows.r.mu.Unlock()
+	if err == io.EOF {
+		return err
+	}
+	if err != nil {
+		rows.q.Err = err.Error()
+		return err
+	}
+	row := make([]interface{}, len(dest))
+	for i, v := range dest {
+		row[i] = copyValue(v)
+	}
+	rows.q.Rows = append(rows.q.Rows, row)
+	return nil
+}
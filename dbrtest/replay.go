@@ -0,0 +1,178 @@
+package dbrtest
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sync"
+
+	"github.com/lianchengwu/dbr"
+)
+
+// Replayer is a driver.Connector replaying recorded queries, use it with sql.OpenDB or Open.
+// Queries must run in the recorded order with the same SQL and args,
+// otherwise they fail and ExpectationsWereMet returns the mismatch.
+type Replayer struct {
+	mu      sync.Mutex
+	queries []Query
+	next    int
+	err     error
+}
+
+// NewReplayer returns a Replayer of queries
+func NewReplayer(queries []Query) *Replayer {
+	return &Replayer{queries: queries}
+}
+
+// Open returns a connection of dialect d replaying queries
+func Open(d dbr.Dialect, queries []Query) (*dbr.Connection, *Replayer) {
+	r := NewReplayer(queries)
+	return &dbr.Connection{DB: sql.OpenDB(r), Dialect: d, EventReceiver: &dbr.NullEventReceiver{}}, r
+}
+
+// Connect implements driver.Connector
+func (r *Replayer) Connect(context.Context) (driver.Conn, error) {
+	return &replayConn{r: r}, nil
+}
+
+// Driver implements driver.Connector
+func (r *Replayer) Driver() driver.Driver {
+	return replayDriver{r: r}
+}
+
+// ExpectationsWereMet returns the first mismatch or an error if some queries did not run
+func (r *Replayer) ExpectationsWereMet() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err != nil {
+		return r.err
+	}
+	if r.next < len(r.queries) {
+		return fmt.Errorf("dbrtest: %d of %d queries did not run, next is %q",
+			len(r.queries)-r.next, len(r.queries), r.queries[r.next].SQL)
+	}
+	return nil
+}
+
+// replay returns the next query if it matches query and args
+func (r *Replayer) replay(query string, args []driver.NamedValue) (*Query, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err != nil {
+		return nil, r.err
+	}
+	var v []interface{}
+	for _, arg := range args {
+		v = append(v, arg.Value)
+	}
+	if r.next >= len(r.queries) {
+		r.err = fmt.Errorf("dbrtest: unexpected query %q, all %d queries ran", query, len(r.queries))
+		return nil, r.err
+	}
+	q := &r.queries[r.next]
+	if q.SQL != query {
+		r.err = fmt.Errorf("dbrtest: query %d: expected %q, got %q", r.next, q.SQL, query)
+		return nil, r.err
+	}
+	if len(q.Args) != len(v) || len(v) > 0 && !reflect.DeepEqual(q.Args, v) {
+		r.err = fmt.Errorf("dbrtest: query %d %q: expected args %v, got %v", r.next, query, q.Args, v)
+		return nil, r.err
+	}
+	r.next++
+	return q, nil
+}
+
+type replayDriver struct {
+	r *Replayer
+}
+
+func (d replayDriver) Open(string) (driver.Conn, error) {
+	return &replayConn{r: d.r}, nil
+}
+
+type replayConn struct {
+	r *Replayer
+}
+
+func (c *replayConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("dbrtest: prepare is not supported")
+}
+
+func (c *replayConn) Close() error {
+	return nil
+}
+
+func (c *replayConn) Begin() (driver.Tx, error) {
+	return replayTx{}, nil
+}
+
+func (c *replayConn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	q, err := c.r.replay(query, args)
+	if err != nil {
+		return nil, err
+	}
+	if q.Err != "" {
+		return nil, errors.New(q.Err)
+	}
+	return replayResult{q: q}, nil
+}
+
+func (c *replayConn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	q, err := c.r.replay(query, args)
+	if err != nil {
+		return nil, err
+	}
+	if q.Err != "" && q.Columns == nil {
+		return nil, errors.New(q.Err)
+	}
+	return &replayRows{q: q}, nil
+}
+
+type replayTx struct{}
+
+func (replayTx) Commit() error   { return nil }
+func (replayTx) Rollback() error { return nil }
+
+type replayResult struct {
+	q *Query
+}
+
+func (r replayResult) LastInsertId() (int64, error) {
+	return r.q.LastInsertID, nil
+}
+
+func (r replayResult) RowsAffected() (int64, error) {
+	return r.q.RowsAffected, nil
+}
+
+type replayRows struct {
+	q    *Query
+	next int
+}
+
+func (rows *replayRows) Columns() []string {
+	return rows.q.Columns
+}
+
+func (rows *replayRows) Close() error {
+	return nil
+}
+
+func (rows *replayRows) Next(dest []driver.Value) error {
+	if rows.next >= len(rows.q.Rows) {
+		// an error while reading rows is recorded after the rows
+		if rows.q.Err != "" {
+			return errors.New(rows.q.Err)
+		}
+		return io.EOF
+	}
+	for i, v := range rows.q.Rows[rows.next] {
+		dest[i] = v
+	}
+	rows.next++
+	return nil
+}
@@ -0,0 +1,104 @@
+package dbrtest
+
+import (
+	"bytes"
+	"database/sql"
+	"testing"
+
+	"github.com/lianchengwu/dbr"
+	"github.com/lianchengwu/dbr/dialect"
+	"github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+)
+
+type person struct {
+	ID   int64
+	Name string
+}
+
+// run is the code under test
+func run(sess *dbr.Session, name string) (person, error) {
+	var p person
+	result, err := sess.InsertInto("people").Columns("name").Values(name).Exec()
+	if err != nil {
+		return p, err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return p, err
+	}
+	err = sess.Select("*").From("people").Where(dbr.Eq("id", id)).LoadStruct(&p)
+	return p, err
+}
+
+func record(t *testing.T) []Query {
+	rec := NewRecorder(&sqlite3.SQLiteDriver{}, ":memory:")
+	db := sql.OpenDB(rec)
+	defer db.Close()
+	// one connection keeps the in-memory database
+	db.SetMaxOpenConns(1)
+	_, err := db.Exec("CREATE TABLE people (id INTEGER PRIMARY KEY, name varchar(255))")
+	assert.NoError(t, err)
+
+	conn := &dbr.Connection{DB: db, Dialect: dialect.SQLite3, EventReceiver: &dbr.NullEventReceiver{}}
+	sess := conn.NewSession(nil)
+	sess.DisableInterpolation = true
+	p, err := run(sess, "jonathan")
+	assert.NoError(t, err)
+	assert.Equal(t, person{ID: 1, Name: "jonathan"}, p)
+
+	queries := rec.Queries()
+	assert.Len(t, queries, 3)
+	return queries[1:]
+}
+
+func TestRecordReplay(t *testing.T) {
+	queries := record(t)
+	assert.Equal(t, []Query{
+		{SQL: `INSERT INTO "people" ("name") VALUES (?)`, Args: []interface{}{"jonathan"}, LastInsertID: 1, RowsAffected: 1},
+		{SQL: `SELECT * FROM people WHERE ("id" = ?)`, Args: []interface{}{int64(1)},
+			Columns: []string{"id", "name"}, Rows: [][]interface{}{{int64(1), "jonathan"}}},
+	}, queries)
+
+	buf := new(bytes.Buffer)
+	assert.NoError(t, WriteQueries(buf, queries))
+	queries, err := ReadQueries(buf)
+	assert.NoError(t, err)
+
+	conn, replay := Open(dialect.SQLite3, queries)
+	sess := conn.NewSession(nil)
+	sess.DisableInterpolation = true
+	p, err := run(sess, "jonathan")
+	assert.NoError(t, err)
+	assert.Equal(t, person{ID: 1, Name: "jonathan"}, p)
+	assert.NoError(t, replay.ExpectationsWereMet())
+
+	// all queries must run
+	conn, replay = Open(dialect.SQLite3, queries)
+	_, err = conn.NewSession(nil).InsertInto("people").Columns("name").Values("jonathan").Exec()
+	assert.Error(t, err)
+	assert.Error(t, replay.ExpectationsWereMet())
+}
+
+func TestReplayMismatch(t *testing.T) {
+	queries := record(t)
+
+	// args differ
+	conn, replay := Open(dialect.SQLite3, queries)
+	sess := conn.NewSession(nil)
+	sess.DisableInterpolation = true
+	_, err := run(sess, "john")
+	assert.Error(t, err)
+	assert.EqualError(t, replay.ExpectationsWereMet(),
+		`dbrtest: query 0 "INSERT INTO \"people\" (\"name\") VALUES (?)": expected args [jonathan], got [john]`)
+
+	// order differs
+	conn, replay = Open(dialect.SQLite3, queries)
+	sess = conn.NewSession(nil)
+	sess.DisableInterpolation = true
+	var p person
+	err = sess.Select("*").From("people").Where(dbr.Eq("id", 1)).LoadStruct(&p)
+	assert.Error(t, err)
+	assert.EqualError(t, replay.ExpectationsWereMet(),
+		`dbrtest: query 0: expected "INSERT INTO \"people\" (\"name\") VALUES (?)", got "SELECT * FROM people WHERE (\"id\" = ?)"`)
+}
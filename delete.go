@@ -4,6 +4,8 @@ package dbr
 type DeleteStmt interface {
 	Builder
 	Where(query interface{}, value ...interface{}) DeleteStmt
+	AllRows() DeleteStmt
+	Shard(key interface{}) DeleteStmt
 }
 
 type deleteStmt struct {
@@ -11,6 +13,9 @@ type deleteStmt struct {
 
 	Table     string
 	WhereCond []Builder
+	IsAllRows bool
+	ShardKey  interface{}
+	IsSharded bool
 }
 
 // Build builds `DELETE ...` in dialect
@@ -23,8 +28,17 @@ func (b *deleteStmt) Build(d Dialect, buf Buffer) error {
 		return ErrTableNotSpecified
 	}
 
+	table := b.Table
+	if b.IsSharded {
+		var err error
+		table, err = resolveTable(d, b.Table, b.ShardKey)
+		if err != nil {
+			return err
+		}
+	}
+
 	buf.WriteString("DELETE FROM ")
-	buf.WriteString(d.QuoteIdent(b.Table))
+	buf.WriteString(d.QuoteIdent(table))
 
 	if len(b.WhereCond) > 0 {
 		buf.WriteString(" WHERE ")
@@ -71,3 +85,21 @@ func (b *deleteStmt) Where(query interface{}, value ...interface{}) DeleteStmt {
 	}
 	return b
 }
+
+// AllRows allows the stmt without WHERE to delete all rows, see Session.RequireWhere
+func (b *deleteStmt) AllRows() DeleteStmt {
+	b.IsAllRows = true
+	return b
+}
+
+// Shard makes the table the physical table for the shard key, resolved with Session.TableResolver
+func (b *deleteStmt) Shard(key interface{}) DeleteStmt {
+	b.ShardKey = key
+	b.IsSharded = true
+	return b
+}
+
+// missingWhere reports whether the stmt affects all rows without AllRows
+func (b *deleteStmt) missingWhere() bool {
+	return b.raw.Query == "" && !b.IsAllRows && matchAllRows(b.WhereCond)
+}
@@ -12,7 +12,9 @@ type DeleteBuilder interface {
 	Executer
 
 	Where(query interface{}, value ...interface{}) DeleteBuilder
+	AllRows() DeleteBuilder
 	Limit(n uint64) DeleteBuilder
+	Shard(key interface{}) DeleteBuilder
 }
 
 type deleteBuilder struct {
@@ -79,6 +81,18 @@ func (b *deleteBuilder) Where(query interface{}, value ...interface{}) DeleteBui
 	return b
 }
 
+// AllRows allows the stmt without WHERE to delete all rows, see Session.RequireWhere
+func (b *deleteBuilder) AllRows() DeleteBuilder {
+	b.deleteStmt.AllRows()
+	return b
+}
+
+// Shard makes the table the physical table for the shard key, see Session.TableResolver
+func (b *deleteBuilder) Shard(key interface{}) DeleteBuilder {
+	b.deleteStmt.Shard(key)
+	return b
+}
+
 // Limit adds LIMIT
 func (b *deleteBuilder) Limit(n uint64) DeleteBuilder {
 	b.LimitCount = int64(n)
@@ -87,7 +101,10 @@ func (b *deleteBuilder) Limit(n uint64) DeleteBuilder {
 
 // Build builds `DELETE ...` in dialect
 func (b *deleteBuilder) Build(d Dialect, buf Buffer) error {
-	err := b.deleteStmt.Build(b.Dialect, buf)
+	if b.runner.getSession().RequireWhere && b.deleteStmt.missingWhere() {
+		return ErrMissingWhere
+	}
+	err := b.deleteStmt.Build(d, buf)
 	if err != nil {
 		return err
 	}
@@ -16,6 +16,43 @@ func TestDeleteStmt(t *testing.T) {
 	assert.Equal(t, []interface{}{1}, buf.Value())
 }
 
+func TestDeleteRequireWhere(t *testing.T) {
+	sess, fake := newFakeSession(dialect.MySQL)
+
+	// allowed by default
+	_, err := sess.DeleteFrom("users").Exec()
+	assert.NoError(t, err)
+
+	sess.RequireWhere = true
+	_, err = sess.DeleteFrom("users").Exec()
+	assert.Equal(t, ErrMissingWhere, err)
+	_, err = sess.DeleteFrom("users").Where(And()).Exec()
+	assert.Equal(t, ErrMissingWhere, err)
+	_, err = sess.Update("users").Set("a", 1).Exec()
+	assert.Equal(t, ErrMissingWhere, err)
+
+	_, err = sess.DeleteFrom("users").Where(Eq("id", 1)).Exec()
+	assert.NoError(t, err)
+	_, err = sess.DeleteFrom("users").AllRows().Exec()
+	assert.NoError(t, err)
+	_, err = sess.Update("users").Set("a", 1).AllRows().Exec()
+	assert.NoError(t, err)
+	_, err = sess.DeleteBySql("DELETE FROM users").Exec()
+	assert.NoError(t, err)
+
+	var query []string
+	for _, stmt := range fake.statements() {
+		query = append(query, stmt.query)
+	}
+	assert.Equal(t, []string{
+		"DELETE FROM `users`",
+		"DELETE FROM `users` WHERE (`id` = 1)",
+		"DELETE FROM `users`",
+		"UPDATE `users` SET `a` = 1",
+		"DELETE FROM users",
+	}, query)
+}
+
 func BenchmarkDeleteSQL(b *testing.B) {
 	buf := NewBuffer()
 	for i := 0; i < b.N; i++ {
@@ -2,7 +2,10 @@ package dbr
 
 import "time"
 
-// Dialect abstracts database differences
+// Dialect abstracts database differences.
+// Features added after it are optional interfaces of Dialect (e.g. ReturningDialect),
+// so dialects implemented outside of dbr keep compiling. Features of dialects without the optional
+// interface are not supported, they return ErrNotSupported or use a fallback, as documented by the features.
 type Dialect interface {
 	QuoteIdent(id string) string
 
@@ -16,3 +19,198 @@ type Dialect interface {
 	Limit(offset, limit int64) string
 	Prewhere() string
 }
+
+// CondDialect is an optional interface of Dialect encoding constant conditions,
+// e.g. `1 = 1` where booleans are not expressions. EncodeBool is used without it.
+type CondDialect interface {
+	EncodeCond(b bool) string
+}
+
+// DurationDialect is an optional interface of Dialect encoding time.Duration, see Session.DurationUnit
+type DurationDialect interface {
+	EncodeDuration(d time.Duration) string
+}
+
+// InsertIgnoreDialect is an optional interface of Dialect for InsertStmt.Ignore,
+// column is the conflict target, or empty for any constraint.
+type InsertIgnoreDialect interface {
+	InsertIgnore(column []string) (insert, conflict string)
+}
+
+// ForUpdateDialect is an optional interface of Dialect for the row lock clause of SelectStmt.ForUpdate,
+// an empty clause means that rows are not locked. FOR UPDATE is used without it.
+type ForUpdateDialect interface {
+	ForUpdate() string
+}
+
+// ConsistencyDialect is an optional interface of Dialect for SelectStmt.Consistency
+type ConsistencyDialect interface {
+	Consistency(level string) string
+}
+
+// TupleInDialect is an optional interface of Dialect for row values in InTuple
+type TupleInDialect interface {
+	SupportsTupleIn() bool
+}
+
+// AggregateFilterDialect is an optional interface of Dialect for FILTER of AggregateFilter
+type AggregateFilterDialect interface {
+	SupportsAggregateFilter() bool
+}
+
+// WindowDialect is an optional interface of Dialect for window functions, e.g. of RunningTotal and LoadPage
+type WindowDialect interface {
+	SupportsWindow() bool
+}
+
+// ReturningDialect is an optional interface of Dialect for RETURNING
+type ReturningDialect interface {
+	SupportsReturning() bool
+}
+
+// UpdateFromDialect is an optional interface of Dialect for UPDATE ... FROM, see UpdateStmt.From
+type UpdateFromDialect interface {
+	SupportsUpdateFrom() bool
+}
+
+// MultiTableUpdateDialect is an optional interface of Dialect for UPDATE of joined tables, see UpdateStmt.Join
+type MultiTableUpdateDialect interface {
+	SupportsMultiTableUpdate() bool
+}
+
+// UnnestDialect is an optional interface of Dialect for Unnest
+type UnnestDialect interface {
+	SupportsUnnest() bool
+}
+
+// MergeDialect is an optional interface of Dialect for MERGE.
+// Dual is the table of selects without a table (e.g. dual in Oracle), or empty if VALUES lists are supported.
+// SupportsMatchedDelete reports whether `WHEN MATCHED THEN DELETE` is supported.
+type MergeDialect interface {
+	SupportsMerge() bool
+	SupportsMatchedDelete() bool
+	Dual() string
+}
+
+// CreateTableAsDialect is an optional interface of Dialect for CreateTableAs
+type CreateTableAsDialect interface {
+	CreateTableAs(table string, temporary bool) string
+}
+
+// SelectIntoDialect is an optional interface of Dialect creating tables of CreateTableAs with
+// `SELECT ... INTO table FROM ...` (e.g. SQL Server), SelectInto returns the INTO clause.
+// Queries which are not built with Select use CreateTableAsDialect.
+type SelectIntoDialect interface {
+	SelectInto(table string, temporary bool) string
+}
+
+// TempTableDialect is an optional interface of Dialect for Tx.CreateTempTable
+type TempTableDialect interface {
+	CreateTempTable(table string, column []string) (create, drop string)
+}
+
+// JSONDialect is an optional interface of Dialect for JSONAgg, JSONObjectAgg and JSONObject
+type JSONDialect interface {
+	JSONAgg(expr string) string
+	JSONObjectAgg(key, value string) string
+	JSONObject(pair []string) string
+}
+
+// UnaccentDialect is an optional interface of Dialect for Unaccent
+type UnaccentDialect interface {
+	Unaccent(column, value string) string
+}
+
+// LikeEscapeDialect is an optional interface of Dialect for the ESCAPE clause of LikeEscape.
+// Only the default escape character (backslash) is supported without it.
+type LikeEscapeDialect interface {
+	LikeEscape(escape string) string
+}
+
+// IndexHintDialect is an optional interface of Dialect for SelectStmt.IndexHint
+type IndexHintDialect interface {
+	IndexHint(hint string, index []string) string
+}
+
+// TableSampleDialect is an optional interface of Dialect for SelectStmt.TableSample
+type TableSampleDialect interface {
+	TableSample(method string, percent float64) string
+}
+
+// ResetDialect is an optional interface of Dialect for Session.Reset,
+// restore is run after query if the first query, which saves the settings, succeeded.
+type ResetDialect interface {
+	ResetTables(table []string) (query, restore []string)
+}
+
+// SavepointDialect is an optional interface of Dialect for Tx.Savepoint
+type SavepointDialect interface {
+	Savepoint(name string) (savepoint, rollback, release string)
+}
+
+// ConstraintsDialect is an optional interface of Dialect for Tx.SetConstraints
+type ConstraintsDialect interface {
+	SetConstraints(deferred bool) string
+}
+
+// CastDialect is an optional interface of Dialect for InsertStmt.Cast
+type CastDialect interface {
+	Cast(typ string) string
+}
+
+// RandomDialect is an optional interface of Dialect for SelectStmt.OrderByRandom
+type RandomDialect interface {
+	Random() string
+}
+
+// DistinctFromDialect is an optional interface of Dialect for IsDistinctFrom and IsNotDistinctFrom
+type DistinctFromDialect interface {
+	DistinctFrom(left, right string, not bool) string
+}
+
+// ErrorClassDialect is an optional interface of Dialect for ClassifyError
+type ErrorClassDialect interface {
+	ClassifyError(err error) string
+}
+
+type placeholderDialect struct {
+	Dialect
+	placeholder func(n int) string
+}
+
+func (d placeholderDialect) Placeholder(n int) string {
+	return d.placeholder(n)
+}
+
+func (d placeholderDialect) unwrap() Dialect {
+	return d.Dialect
+}
+
+// WithPlaceholder wraps d to render placeholders of bound values with placeholder,
+// e.g. for drivers expecting `@p1` or `%1`; n is the 0-based index of the value in the query.
+// Placeholders are written when values are not interpolated, see Session.DisableInterpolation.
+func WithPlaceholder(d Dialect, placeholder func(n int) string) Dialect {
+	return placeholderDialect{Dialect: d, placeholder: placeholder}
+}
+
+// dialectWrapper is a Dialect wrapping another one, e.g. to override a method
+type dialectWrapper interface {
+	unwrap() Dialect
+}
+
+func supportsReturning(d Dialect) bool {
+	r, ok := baseDialect(d).(ReturningDialect)
+	return ok && r.SupportsReturning()
+}
+
+// baseDialect returns the dialect wrapped by d (e.g. with WithPlaceholder) to look up its optional interfaces,
+// which are hidden by the wrappers
+func baseDialect(d Dialect) Dialect {
+	for {
+		w, ok := d.(dialectWrapper)
+		if !ok {
+			return d
+		}
+		d = w.unwrap()
+	}
+}
@@ -64,6 +64,10 @@ func (d clickhouse) EncodeBytes(b []byte) string {
 	return fmt.Sprintf(`0x%x`, b)
 }
 
+func (d clickhouse) EncodeDuration(_ time.Duration) string {
+	return ""
+}
+
 func (d clickhouse) Placeholder(_ int) string {
 	return "?"
 }
@@ -76,6 +80,10 @@ func (d clickhouse) Proposed(_ string) string {
 	return ""
 }
 
+func (d clickhouse) InsertIgnore(_ []string) (string, string) {
+	return "", ""
+}
+
 func (d clickhouse) Limit(offset, limit int64) string {
 	if offset < 0 {
 		return fmt.Sprintf("LIMIT %d", limit)
@@ -90,3 +98,145 @@ func (d clickhouse) String() string {
 func (d clickhouse) Prewhere() string {
 	return "PREWHERE"
 }
+
+func (d clickhouse) ForUpdate() string {
+	return "FOR UPDATE"
+}
+
+// https://clickhouse.com/docs/en/operations/settings/settings#select_sequential_consistency
+func (d clickhouse) Consistency(level string) string {
+	switch level {
+	case "strong":
+		return "SETTINGS select_sequential_consistency = 1"
+	case "eventual":
+		return "SETTINGS select_sequential_consistency = 0"
+	}
+	return ""
+}
+
+func (d clickhouse) SupportsTupleIn() bool {
+	return true
+}
+
+func (d clickhouse) SupportsAggregateFilter() bool {
+	return false
+}
+
+func (d clickhouse) SupportsWindow() bool {
+	return true
+}
+
+func (d clickhouse) SupportsReturning() bool {
+	return false
+}
+
+func (d clickhouse) SupportsUpdateFrom() bool {
+	return false
+}
+
+func (d clickhouse) SupportsMultiTableUpdate() bool {
+	return false
+}
+
+func (d clickhouse) SupportsUnnest() bool {
+	return false
+}
+
+func (d clickhouse) SupportsMerge() bool {
+	return false
+}
+
+func (d clickhouse) SupportsMatchedDelete() bool {
+	return false
+}
+
+func (d clickhouse) Dual() string {
+	return ""
+}
+
+func (d clickhouse) CreateTableAs(_ string, _ bool) string {
+	// engine is required
+	return ""
+}
+
+func (d clickhouse) CreateTempTable(table string, column []string) (create, drop string) {
+	return "", ""
+}
+
+func (d clickhouse) JSONAgg(expr string) string {
+	return ""
+}
+
+func (d clickhouse) JSONObjectAgg(key, value string) string {
+	return ""
+}
+
+func (d clickhouse) JSONObject(_ []string) string {
+	return ""
+}
+
+func (d clickhouse) Unaccent(_, _ string) string {
+	return ""
+}
+
+// LIKE has no ESCAPE clause, wildcards are escaped with backslash
+func (d clickhouse) LikeEscape(_ string) string {
+	return ""
+}
+
+func (d clickhouse) IndexHint(hint string, index []string) string {
+	return ""
+}
+
+// SAMPLE requires a sampling key of the table, the method is ignored
+func (d clickhouse) TableSample(_ string, percent float64) string {
+	return fmt.Sprintf("SAMPLE %g", percent/100)
+}
+
+func (d clickhouse) ResetTables(table []string) (query, restore []string) {
+	for _, t := range table {
+		query = append(query, "TRUNCATE TABLE "+d.QuoteIdent(t))
+	}
+	return query, nil
+}
+
+func (d clickhouse) Savepoint(_ string) (savepoint, rollback, release string) {
+	return "", "", ""
+}
+
+func (d clickhouse) SetConstraints(_ bool) string {
+	return ""
+}
+
+func (d clickhouse) Cast(typ string) string {
+	return ""
+}
+
+func (d clickhouse) Random() string {
+	return "rand()"
+}
+
+func (d clickhouse) DistinctFrom(left, right string, not bool) string {
+	return ""
+}
+
+func (d clickhouse) EncodeCond(b bool) string {
+	return d.EncodeBool(b)
+}
+
+func (d clickhouse) ClassifyError(err error) string {
+	// clickhouse-go formats exceptions as "code: 159, message: ..." or "Code: 159. DB::Exception: ..."
+	code := errorCode(err, "code: ")
+	if code == 0 {
+		code = errorCode(err, "Code: ")
+	}
+	switch code {
+	case 473:
+		// DEADLOCK_AVOIDED
+		return errorDeadlock
+	case 159:
+		// TIMEOUT_EXCEEDED
+		return errorTimeout
+	}
+	return ""
+}
@@ -1,15 +1,20 @@
 package dialect
 
-import "strings"
+import (
+	"fmt"
+	"strings"
+)
 
 var (
 	//ClickHouse dialect
 	ClickHouse = clickhouse{}
 	// MySQL dialect
 	MySQL = mysql{}
+	// Oracle dialect
+	Oracle = oracle{}
 	// PostgreSQL dialect
 	PostgreSQL = postgreSQL{}
-	// SQLite3 dialect
+	// SQLite3 dialect for SQLite 3.29, see SQLite3Version for newer versions
 	SQLite3 = sqlite3{}
 )
 
@@ -22,5 +27,36 @@ func quoteIdent(s, quote string) string {
 	if len(part) == 2 {
 		return quoteIdent(part[0], quote) + "." + quoteIdent(part[1], quote)
 	}
+	if s == "*" {
+		// all columns, e.g. users.*
+		return s
+	}
 	return quote + s + quote
 }
+
+// error classes returned by ClassifyError, they match dbr.ErrorClass constants
+const (
+	errorDeadlock        = "deadlock"
+	errorTimeout         = "timeout"
+	errorUniqueViolation = "unique_violation"
+)
+
+// sqlState returns SQLSTATE of err if the driver exposes it (e.g. pgx)
+func sqlState(err error) string {
+	if e, ok := err.(interface{ SQLState() string }); ok {
+		return e.SQLState()
+	}
+	return ""
+}
+
+// errorCode returns the number following prefix in the message of err, or 0
+func errorCode(err error, prefix string) int {
+	msg := err.Error()
+	i := strings.Index(msg, prefix)
+	if i < 0 {
+		return 0
+	}
+	var code int
+	fmt.Sscanf(msg[i+len(prefix):], "%d", &code)
+	return code
+}
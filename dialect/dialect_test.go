@@ -1,6 +1,7 @@
 package dialect
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -58,4 +59,85 @@ func TestSQLite3(t *testing.T) {
 	} {
 		assert.Equal(t, test.want, SQLite3.QuoteIdent(test.in))
 	}
+
+	assert.False(t, SQLite3.SupportsAggregateFilter())
+	assert.True(t, SQLite3Version(3, 30).SupportsAggregateFilter())
+	assert.True(t, SQLite3Version(4, 0).SupportsAggregateFilter())
+	assert.False(t, SQLite3.SupportsReturning())
+	assert.False(t, SQLite3Version(3, 34).SupportsReturning())
+	assert.True(t, SQLite3Version(3, 35).SupportsReturning())
+	assert.False(t, SQLite3.SupportsUpdateFrom())
+	assert.True(t, SQLite3Version(3, 33).SupportsUpdateFrom())
+}
+
+func TestOracle(t *testing.T) {
+	for _, test := range []struct {
+		in   string
+		want string
+	}{
+		{
+			in:   "table.col",
+			want: `"table"."col"`,
+		},
+		{
+			in:   "col",
+			want: `"col"`,
+		},
+	} {
+		assert.Equal(t, test.want, Oracle.QuoteIdent(test.in))
+	}
+}
+
+func TestLimit(t *testing.T) {
+	for _, test := range []struct {
+		offset, limit int64
+		mysql         string
+		oracle        string
+	}{
+		{
+			offset: -1,
+			limit:  3,
+			mysql:  "LIMIT 3",
+			oracle: "FETCH FIRST 3 ROWS ONLY",
+		},
+		{
+			offset: 4,
+			limit:  3,
+			mysql:  "LIMIT 4,3",
+			oracle: "OFFSET 4 ROWS FETCH FIRST 3 ROWS ONLY",
+		},
+	} {
+		assert.Equal(t, test.mysql, MySQL.Limit(test.offset, test.limit))
+		assert.Equal(t, test.oracle, Oracle.Limit(test.offset, test.limit))
+	}
+}
+
+type pgxError struct{ code string }
+
+func (e pgxError) Error() string    { return "ERROR: deadlock detected" }
+func (e pgxError) SQLState() string { return e.code }
+
+func TestClassifyError(t *testing.T) {
+	for _, test := range []struct {
+		dialect interface{ ClassifyError(error) string }
+		err     error
+		want    string
+	}{
+		{MySQL, errors.New("Error 1213: Deadlock found when trying to get lock; try restarting transaction"), errorDeadlock},
+		{MySQL, errors.New("Error 1205 (HY000): Lock wait timeout exceeded; try restarting transaction"), errorTimeout},
+		{MySQL, errors.New("Error 1062: Duplicate entry '1' for key 'PRIMARY'"), errorUniqueViolation},
+		{MySQL, errors.New("Error 1146: Table 'db.t' doesn't exist"), ""},
+		{MySQL, errors.New("sql: no rows in result set"), ""},
+		{PostgreSQL, pgxError{code: "40P01"}, errorDeadlock},
+		{PostgreSQL, errors.New(`ERROR: duplicate key value violates unique constraint "t_pkey" (SQLSTATE 23505)`), errorUniqueViolation},
+		{PostgreSQL, errors.New("pq: canceling statement due to statement timeout"), errorTimeout},
+		{SQLite3, errors.New("database is locked"), errorTimeout},
+		{SQLite3, errors.New("UNIQUE constraint failed: t.id"), errorUniqueViolation},
+		{Oracle, errors.New("ORA-00060: deadlock detected while waiting for resource"), errorDeadlock},
+		{Oracle, errors.New("ORA-00001: unique constraint (T_PK) violated"), errorUniqueViolation},
+		{Oracle, errors.New("ORA-00942: table or view does not exist"), ""},
+		{ClickHouse, errors.New("code: 159, message: Timeout exceeded: elapsed 5.1 seconds"), errorTimeout},
+	} {
+		assert.Equal(t, test.want, test.dialect.ClassifyError(test.err), test.err.Error())
+	}
 }
@@ -3,6 +3,7 @@ package dialect
 import (
 	"bytes"
 	"fmt"
+	"strings"
 	"time"
 )
 
@@ -61,6 +62,10 @@ func (d mysql) EncodeBytes(b []byte) string {
 	return fmt.Sprintf(`0x%x`, b)
 }
 
+func (d mysql) EncodeDuration(_ time.Duration) string {
+	return ""
+}
+
 func (d mysql) Placeholder(_ int) string {
 	return "?"
 }
@@ -73,6 +78,11 @@ func (d mysql) Proposed(column string) string {
 	return fmt.Sprintf("VALUES(%s)", d.QuoteIdent(column))
 }
 
+// INSERT IGNORE also turns other errors into warnings, e.g. invalid values
+func (d mysql) InsertIgnore(_ []string) (string, string) {
+	return "INSERT IGNORE INTO", ""
+}
+
 func (d mysql) Limit(offset, limit int64) string {
 	if offset < 0 {
 		return fmt.Sprintf("LIMIT %d", limit)
@@ -83,3 +93,154 @@ func (d mysql) Limit(offset, limit int64) string {
 func (d mysql) Prewhere() string {
 	return ""
 }
+
+func (d mysql) ForUpdate() string {
+	return "FOR UPDATE"
+}
+
+func (d mysql) Consistency(_ string) string {
+	return ""
+}
+
+func (d mysql) SupportsTupleIn() bool {
+	return true
+}
+
+func (d mysql) SupportsAggregateFilter() bool {
+	return false
+}
+
+// window functions require MySQL 8, correlated subqueries work in all versions
+func (d mysql) SupportsWindow() bool {
+	return false
+}
+
+func (d mysql) SupportsReturning() bool {
+	return false
+}
+
+func (d mysql) SupportsUpdateFrom() bool {
+	return false
+}
+
+func (d mysql) SupportsMultiTableUpdate() bool {
+	return true
+}
+
+func (d mysql) SupportsUnnest() bool {
+	return false
+}
+
+func (d mysql) SupportsMerge() bool {
+	return false
+}
+
+func (d mysql) SupportsMatchedDelete() bool {
+	return false
+}
+
+func (d mysql) Dual() string {
+	return ""
+}
+
+func (d mysql) CreateTableAs(table string, temporary bool) string {
+	if temporary {
+		return fmt.Sprintf("CREATE TEMPORARY TABLE %s AS", d.QuoteIdent(table))
+	}
+	return fmt.Sprintf("CREATE TABLE %s AS", d.QuoteIdent(table))
+}
+
+func (d mysql) CreateTempTable(table string, column []string) (create, drop string) {
+	// temporary tables are kept until the connection is closed, even if the transaction is rolled back
+	return fmt.Sprintf("CREATE TEMPORARY TABLE %s (%s)", d.QuoteIdent(table), strings.Join(column, ", ")),
+		fmt.Sprintf("DROP TEMPORARY TABLE IF EXISTS %s", d.QuoteIdent(table))
+}
+
+func (d mysql) JSONAgg(expr string) string {
+	return fmt.Sprintf("JSON_ARRAYAGG(%s)", expr)
+}
+
+func (d mysql) JSONObjectAgg(key, value string) string {
+	return fmt.Sprintf("JSON_OBJECTAGG(%s, %s)", key, value)
+}
+
+func (d mysql) JSONObject(pair []string) string {
+	return fmt.Sprintf("JSON_OBJECT(%s)", strings.Join(pair, ", "))
+}
+
+// accent-insensitive (_ai or _ci) collations of the column ignore accents and case
+func (d mysql) Unaccent(column, value string) string {
+	return fmt.Sprintf("%s LIKE %s", column, value)
+}
+
+func (d mysql) LikeEscape(escape string) string {
+	return "ESCAPE " + d.EncodeString(escape)
+}
+
+func (d mysql) IndexHint(hint string, index []string) string {
+	quoted := make([]string, len(index))
+	for i, idx := range index {
+		quoted[i] = d.QuoteIdent(idx)
+	}
+	return fmt.Sprintf("%s INDEX (%s)", hint, strings.Join(quoted, ", "))
+}
+
+func (d mysql) TableSample(_ string, _ float64) string {
+	return ""
+}
+
+func (d mysql) ResetTables(table []string) (query, restore []string) {
+	// FOREIGN_KEY_CHECKS is per connection, so it is restored to the previous value
+	query = []string{"SET @dbr_foreign_key_checks = @@FOREIGN_KEY_CHECKS, FOREIGN_KEY_CHECKS = 0"}
+	for _, t := range table {
+		// TRUNCATE commits implicitly
+		query = append(query, "DELETE FROM "+d.QuoteIdent(t))
+	}
+	return query, []string{"SET FOREIGN_KEY_CHECKS = @dbr_foreign_key_checks"}
+}
+
+func (d mysql) Savepoint(name string) (savepoint, rollback, release string) {
+	name = d.QuoteIdent(name)
+	return "SAVEPOINT " + name, "ROLLBACK TO SAVEPOINT " + name, "RELEASE SAVEPOINT " + name
+}
+
+func (d mysql) SetConstraints(_ bool) string {
+	return ""
+}
+
+func (d mysql) Cast(typ string) string {
+	return ""
+}
+
+func (d mysql) Random() string {
+	return "RAND()"
+}
+
+func (d mysql) DistinctFrom(left, right string, not bool) string {
+	// MySQL has the NULL-safe equal operator only
+	if not {
+		return left + " <=> " + right
+	}
+	return "NOT (" + left + " <=> " + right + ")"
+}
+
+func (d mysql) EncodeCond(b bool) string {
+	return d.EncodeBool(b)
+}
+
+func (d mysql) ClassifyError(err error) string {
+	// go-sql-driver/mysql formats errors as "Error 1213: ..." or "Error 1213 (40001): ..."
+	if !strings.HasPrefix(err.Error(), "Error ") {
+		return ""
+	}
+	switch errorCode(err, "Error ") {
+	case 1213:
+		return errorDeadlock
+	case 1205, 3024:
+		// lock wait timeout, max_execution_time exceeded
+		return errorTimeout
+	case 1062, 1586:
+		return errorUniqueViolation
+	}
+	return ""
+}
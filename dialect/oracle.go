@@ -0,0 +1,219 @@
+package dialect
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+type oracle struct{}
+
+func (d oracle) QuoteIdent(s string) string {
+	return quoteIdent(s, `"`)
+}
+
+func (d oracle) EncodeString(s string) string {
+	// https://docs.oracle.com/en/database/oracle/oracle-database/19/sqlrf/Literals.html
+	return `'` + strings.Replace(s, `'`, `''`, -1) + `'`
+}
+
+func (d oracle) EncodeBool(b bool) string {
+	// oracle has no boolean type in sql
+	if b {
+		return "1"
+	}
+	return "0"
+}
+
+func (d oracle) EncodeTime(t time.Time) string {
+	return `TIMESTAMP '` + t.UTC().Format(timeFormat) + `'`
+}
+
+func (d oracle) EncodeBytes(b []byte) string {
+	return fmt.Sprintf(`hextoraw('%x')`, b)
+}
+
+func (d oracle) EncodeDuration(_ time.Duration) string {
+	return ""
+}
+
+func (d oracle) Placeholder(n int) string {
+	return fmt.Sprintf(":%d", n+1)
+}
+
+func (d oracle) OnConflict(_ string) string {
+	return ""
+}
+
+func (d oracle) Proposed(_ string) string {
+	return ""
+}
+
+func (d oracle) InsertIgnore(_ []string) (string, string) {
+	return "", ""
+}
+
+func (d oracle) Limit(offset, limit int64) string {
+	// SQL:2008 standard form, oracle does not support LIMIT
+	if offset < 0 {
+		return fmt.Sprintf("FETCH FIRST %d ROWS ONLY", limit)
+	}
+	return fmt.Sprintf("OFFSET %d ROWS FETCH FIRST %d ROWS ONLY", offset, limit)
+}
+
+func (d oracle) Prewhere() string {
+	return ""
+}
+
+func (d oracle) ForUpdate() string {
+	return "FOR UPDATE"
+}
+
+func (d oracle) Consistency(_ string) string {
+	return ""
+}
+
+func (d oracle) SupportsTupleIn() bool {
+	return true
+}
+
+func (d oracle) SupportsAggregateFilter() bool {
+	return false
+}
+
+func (d oracle) SupportsWindow() bool {
+	return true
+}
+
+// RETURNING INTO needs output binds
+func (d oracle) SupportsReturning() bool {
+	return false
+}
+
+func (d oracle) SupportsUpdateFrom() bool {
+	return false
+}
+
+func (d oracle) SupportsMultiTableUpdate() bool {
+	return false
+}
+
+func (d oracle) SupportsUnnest() bool {
+	return false
+}
+
+func (d oracle) SupportsMerge() bool {
+	return true
+}
+
+func (d oracle) SupportsMatchedDelete() bool {
+	// DELETE is only allowed as a DELETE WHERE clause of WHEN MATCHED THEN UPDATE
+	return false
+}
+
+func (d oracle) Dual() string {
+	// FROM is required, and VALUES lists are not supported before Oracle 23c
+	return "dual"
+}
+
+func (d oracle) CreateTableAs(table string, temporary bool) string {
+	if temporary {
+		// global temporary tables are created once as a part of schema
+		return ""
+	}
+	return fmt.Sprintf("CREATE TABLE %s AS", d.QuoteIdent(table))
+}
+
+func (d oracle) CreateTempTable(table string, column []string) (create, drop string) {
+	// global temporary tables are created once as a part of schema
+	return "", ""
+}
+
+func (d oracle) JSONAgg(expr string) string {
+	return fmt.Sprintf("JSON_ARRAYAGG(%s)", expr)
+}
+
+func (d oracle) JSONObjectAgg(key, value string) string {
+	return fmt.Sprintf("JSON_OBJECTAGG(KEY %s VALUE %s)", key, value)
+}
+
+func (d oracle) JSONObject(pair []string) string {
+	kv := make([]string, 0, len(pair)/2)
+	for i := 0; i+1 < len(pair); i += 2 {
+		kv = append(kv, fmt.Sprintf("KEY %s VALUE %s", pair[i], pair[i+1]))
+	}
+	return fmt.Sprintf("JSON_OBJECT(%s)", strings.Join(kv, ", "))
+}
+
+func (d oracle) Unaccent(_, _ string) string {
+	return ""
+}
+
+func (d oracle) LikeEscape(escape string) string {
+	return "ESCAPE " + d.EncodeString(escape)
+}
+
+func (d oracle) IndexHint(hint string, index []string) string {
+	return ""
+}
+
+func (d oracle) TableSample(method string, percent float64) string {
+	if method == "SYSTEM" {
+		return fmt.Sprintf("SAMPLE BLOCK (%g)", percent)
+	}
+	return fmt.Sprintf("SAMPLE (%g)", percent)
+}
+
+func (d oracle) ResetTables(table []string) (query, restore []string) {
+	return nil, nil
+}
+
+// savepoints are released by the end of the transaction
+func (d oracle) Savepoint(name string) (savepoint, rollback, release string) {
+	name = d.QuoteIdent(name)
+	return "SAVEPOINT " + name, "ROLLBACK TO SAVEPOINT " + name, ""
+}
+
+func (d oracle) SetConstraints(deferred bool) string {
+	if deferred {
+		return "SET CONSTRAINTS ALL DEFERRED"
+	}
+	return "SET CONSTRAINTS ALL IMMEDIATE"
+}
+
+func (d oracle) Cast(typ string) string {
+	return ""
+}
+
+func (d oracle) Random() string {
+	return "DBMS_RANDOM.VALUE"
+}
+
+func (d oracle) DistinctFrom(left, right string, not bool) string {
+	// DECODE compares NULLs as equal
+	if not {
+		return "DECODE(" + left + ", " + right + ", 0, 1) = 0"
+	}
+	return "DECODE(" + left + ", " + right + ", 0, 1) = 1"
+}
+
+func (d oracle) EncodeCond(b bool) string {
+	// oracle has no boolean expressions, e.g. WHERE 1
+	if b {
+		return "1 = 1"
+	}
+	return "1 = 0"
+}
+
+func (d oracle) ClassifyError(err error) string {
+	switch errorCode(err, "ORA-") {
+	case 60:
+		return errorDeadlock
+	case 1013, 30006:
+		// user requested cancel, resource busy with WAIT timeout
+		return errorTimeout
+	case 1:
+		return errorUniqueViolation
+	}
+	return ""
+}
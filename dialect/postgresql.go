@@ -32,6 +32,11 @@ func (d postgreSQL) EncodeBytes(b []byte) string {
 	return fmt.Sprintf(`E'\\x%x'`, b)
 }
 
+func (d postgreSQL) EncodeDuration(t time.Duration) string {
+	// microseconds are the resolution of interval
+	return fmt.Sprintf("INTERVAL '%d microseconds'", t/time.Microsecond)
+}
+
 func (d postgreSQL) Placeholder(n int) string {
 	return fmt.Sprintf("$%d", n+1)
 }
@@ -44,6 +49,17 @@ func (d postgreSQL) Proposed(column string) string {
 	return fmt.Sprintf("EXCLUDED.%s", d.QuoteIdent(column))
 }
 
+func (d postgreSQL) InsertIgnore(column []string) (string, string) {
+	if len(column) == 0 {
+		return "INSERT INTO", "ON CONFLICT DO NOTHING"
+	}
+	quoted := make([]string, len(column))
+	for i, col := range column {
+		quoted[i] = d.QuoteIdent(col)
+	}
+	return "INSERT INTO", fmt.Sprintf("ON CONFLICT (%s) DO NOTHING", strings.Join(quoted, ","))
+}
+
 func (d postgreSQL) Limit(offset, limit int64) string {
 	if offset < 0 {
 		return fmt.Sprintf("LIMIT %d", limit)
@@ -54,3 +70,161 @@ func (d postgreSQL) Limit(offset, limit int64) string {
 func (d postgreSQL) Prewhere() string {
 	return ""
 }
+
+func (d postgreSQL) ForUpdate() string {
+	return "FOR UPDATE"
+}
+
+func (d postgreSQL) Consistency(_ string) string {
+	return ""
+}
+
+func (d postgreSQL) SupportsTupleIn() bool {
+	return true
+}
+
+func (d postgreSQL) SupportsAggregateFilter() bool {
+	return true
+}
+
+func (d postgreSQL) SupportsWindow() bool {
+	return true
+}
+
+func (d postgreSQL) SupportsReturning() bool {
+	return true
+}
+
+func (d postgreSQL) SupportsUpdateFrom() bool {
+	return true
+}
+
+func (d postgreSQL) SupportsMultiTableUpdate() bool {
+	return false
+}
+
+func (d postgreSQL) SupportsUnnest() bool {
+	return true
+}
+
+// MERGE requires PostgreSQL 15
+func (d postgreSQL) SupportsMerge() bool {
+	return true
+}
+
+func (d postgreSQL) SupportsMatchedDelete() bool {
+	return true
+}
+
+func (d postgreSQL) Dual() string {
+	return ""
+}
+
+func (d postgreSQL) CreateTableAs(table string, temporary bool) string {
+	if temporary {
+		return fmt.Sprintf("CREATE TEMPORARY TABLE %s AS", d.QuoteIdent(table))
+	}
+	return fmt.Sprintf("CREATE TABLE %s AS", d.QuoteIdent(table))
+}
+
+func (d postgreSQL) CreateTempTable(table string, column []string) (create, drop string) {
+	return fmt.Sprintf("CREATE TEMPORARY TABLE %s (%s) ON COMMIT DROP", d.QuoteIdent(table), strings.Join(column, ", ")), ""
+}
+
+func (d postgreSQL) JSONAgg(expr string) string {
+	return fmt.Sprintf("json_agg(%s)", expr)
+}
+
+func (d postgreSQL) JSONObjectAgg(key, value string) string {
+	return fmt.Sprintf("json_object_agg(%s, %s)", key, value)
+}
+
+func (d postgreSQL) JSONObject(pair []string) string {
+	return fmt.Sprintf("json_build_object(%s)", strings.Join(pair, ", "))
+}
+
+// unaccent requires the extension, CREATE EXTENSION unaccent
+func (d postgreSQL) Unaccent(column, value string) string {
+	return fmt.Sprintf("unaccent(%s) ILIKE unaccent(%s)", column, value)
+}
+
+func (d postgreSQL) LikeEscape(escape string) string {
+	return "ESCAPE " + d.EncodeString(escape)
+}
+
+func (d postgreSQL) IndexHint(hint string, index []string) string {
+	return ""
+}
+
+func (d postgreSQL) TableSample(method string, percent float64) string {
+	return fmt.Sprintf("TABLESAMPLE %s (%g)", method, percent)
+}
+
+func (d postgreSQL) ResetTables(table []string) (query, restore []string) {
+	quoted := make([]string, len(table))
+	for i, t := range table {
+		quoted[i] = d.QuoteIdent(t)
+	}
+	return []string{fmt.Sprintf("TRUNCATE TABLE %s CASCADE", strings.Join(quoted, ", "))}, nil
+}
+
+func (d postgreSQL) Savepoint(name string) (savepoint, rollback, release string) {
+	name = d.QuoteIdent(name)
+	return "SAVEPOINT " + name, "ROLLBACK TO SAVEPOINT " + name, "RELEASE SAVEPOINT " + name
+}
+
+func (d postgreSQL) SetConstraints(deferred bool) string {
+	if deferred {
+		return "SET CONSTRAINTS ALL DEFERRED"
+	}
+	return "SET CONSTRAINTS ALL IMMEDIATE"
+}
+
+func (d postgreSQL) Cast(typ string) string {
+	return "::" + typ
+}
+
+func (d postgreSQL) Random() string {
+	return "RANDOM()"
+}
+
+func (d postgreSQL) DistinctFrom(left, right string, not bool) string {
+	if not {
+		return left + " IS NOT DISTINCT FROM " + right
+	}
+	return left + " IS DISTINCT FROM " + right
+}
+
+func (d postgreSQL) EncodeCond(b bool) string {
+	return d.EncodeBool(b)
+}
+
+func (d postgreSQL) ClassifyError(err error) string {
+	state := sqlState(err)
+	if state == "" {
+		// lib/pq does not expose SQLSTATE through an interface, pgx also writes it to the message
+		msg := err.Error()
+		if i := strings.Index(msg, "(SQLSTATE "); i >= 0 && len(msg) >= i+15 {
+			state = msg[i+10 : i+15]
+		}
+	}
+	switch state {
+	case "40P01":
+		return errorDeadlock
+	case "57014", "55P03":
+		// statement timeout or cancel, lock not available
+		return errorTimeout
+	case "23505":
+		return errorUniqueViolation
+	}
+	msg := err.Error()
+	switch {
+	case strings.Contains(msg, "deadlock detected"):
+		return errorDeadlock
+	case strings.Contains(msg, "canceling statement due to"):
+		return errorTimeout
+	case strings.Contains(msg, "duplicate key value violates unique constraint"):
+		return errorUniqueViolation
+	}
+	return ""
+}
@@ -6,7 +6,21 @@ import (
 	"time"
 )
 
-type sqlite3 struct{}
+type sqlite3 struct {
+	// version is major*1000+minor, zero for SQLite3 which targets SQLite 3.29 of go-sqlite3 v1.11.0
+	version int
+}
+
+// SQLite3Version returns the SQLite3 dialect for SQLite major.minor (see `SELECT sqlite_version()`),
+// which enables the features of newer versions: FILTER of aggregates (3.30), UPDATE ... FROM (3.33) and RETURNING (3.35)
+func SQLite3Version(major, minor int) sqlite3 {
+	return sqlite3{version: major*1000 + minor}
+}
+
+// atLeast returns whether the SQLite version is at least major.minor
+func (d sqlite3) atLeast(major, minor int) bool {
+	return d.version >= major*1000+minor
+}
 
 func (d sqlite3) QuoteIdent(s string) string {
 	return quoteIdent(s, `"`)
@@ -35,6 +49,10 @@ func (d sqlite3) EncodeBytes(b []byte) string {
 	return fmt.Sprintf(`X'%x'`, b)
 }
 
+func (d sqlite3) EncodeDuration(_ time.Duration) string {
+	return ""
+}
+
 func (d sqlite3) Placeholder(_ int) string {
 	return "?"
 }
@@ -47,6 +65,10 @@ func (d sqlite3) Proposed(_ string) string {
 	return ""
 }
 
+func (d sqlite3) InsertIgnore(_ []string) (string, string) {
+	return "INSERT OR IGNORE INTO", ""
+}
+
 func (d sqlite3) Limit(offset, limit int64) string {
 	if offset < 0 {
 		return fmt.Sprintf("LIMIT %d", limit)
@@ -57,3 +79,146 @@ func (d sqlite3) Limit(offset, limit int64) string {
 func (d sqlite3) Prewhere() string {
 	return ""
 }
+
+func (d sqlite3) ForUpdate() string {
+	// transactions lock the database, rows can not be locked
+	return ""
+}
+
+func (d sqlite3) Consistency(_ string) string {
+	return ""
+}
+
+func (d sqlite3) SupportsTupleIn() bool {
+	// https://www.sqlite.org/rowvalue.html, right-hand side of IN must be a subquery
+	return false
+}
+
+func (d sqlite3) SupportsAggregateFilter() bool {
+	return d.atLeast(3, 30)
+}
+
+// window functions require SQLite 3.25
+func (d sqlite3) SupportsWindow() bool {
+	return true
+}
+
+func (d sqlite3) SupportsReturning() bool {
+	return d.atLeast(3, 35)
+}
+
+func (d sqlite3) SupportsUpdateFrom() bool {
+	return d.atLeast(3, 33)
+}
+
+func (d sqlite3) SupportsMultiTableUpdate() bool {
+	return false
+}
+
+func (d sqlite3) SupportsUnnest() bool {
+	return false
+}
+
+func (d sqlite3) SupportsMerge() bool {
+	return false
+}
+
+func (d sqlite3) SupportsMatchedDelete() bool {
+	return false
+}
+
+func (d sqlite3) Dual() string {
+	return ""
+}
+
+func (d sqlite3) CreateTableAs(table string, temporary bool) string {
+	// https://www.sqlite.org/lang_createtable.html
+	if temporary {
+		return fmt.Sprintf("CREATE TEMPORARY TABLE %s AS", d.QuoteIdent(table))
+	}
+	return fmt.Sprintf("CREATE TABLE %s AS", d.QuoteIdent(table))
+}
+
+func (d sqlite3) CreateTempTable(table string, column []string) (create, drop string) {
+	// the temp schema makes sure that a table of the same name in main is not dropped
+	return fmt.Sprintf("CREATE TEMPORARY TABLE %s (%s)", d.QuoteIdent(table), strings.Join(column, ", ")),
+		fmt.Sprintf("DROP TABLE IF EXISTS temp.%s", d.QuoteIdent(table))
+}
+
+func (d sqlite3) JSONAgg(expr string) string {
+	return fmt.Sprintf("json_group_array(%s)", expr)
+}
+
+func (d sqlite3) JSONObjectAgg(key, value string) string {
+	return fmt.Sprintf("json_group_object(%s, %s)", key, value)
+}
+
+func (d sqlite3) JSONObject(pair []string) string {
+	return fmt.Sprintf("json_object(%s)", strings.Join(pair, ", "))
+}
+
+func (d sqlite3) Unaccent(_, _ string) string {
+	return ""
+}
+
+func (d sqlite3) LikeEscape(escape string) string {
+	return "ESCAPE " + d.EncodeString(escape)
+}
+
+func (d sqlite3) IndexHint(hint string, index []string) string {
+	return ""
+}
+
+func (d sqlite3) TableSample(_ string, _ float64) string {
+	return ""
+}
+
+func (d sqlite3) ResetTables(table []string) (query, restore []string) {
+	// foreign keys are checked on commit, and defer_foreign_keys is reset after it
+	query = []string{"PRAGMA defer_foreign_keys = ON"}
+	for _, t := range table {
+		query = append(query, "DELETE FROM "+d.QuoteIdent(t))
+	}
+	return query, nil
+}
+
+func (d sqlite3) Savepoint(name string) (savepoint, rollback, release string) {
+	name = d.QuoteIdent(name)
+	return "SAVEPOINT " + name, "ROLLBACK TO SAVEPOINT " + name, "RELEASE SAVEPOINT " + name
+}
+
+func (d sqlite3) SetConstraints(_ bool) string {
+	return ""
+}
+
+func (d sqlite3) Cast(typ string) string {
+	return ""
+}
+
+func (d sqlite3) Random() string {
+	return "RANDOM()"
+}
+
+func (d sqlite3) DistinctFrom(left, right string, not bool) string {
+	// IS DISTINCT FROM requires SQLite 3.39, IS compares NULLs as equal in all versions
+	if not {
+		return left + " IS " + right
+	}
+	return left + " IS NOT " + right
+}
+
+func (d sqlite3) EncodeCond(b bool) string {
+	return d.EncodeBool(b)
+}
+
+func (d sqlite3) ClassifyError(err error) string {
+	msg := err.Error()
+	switch {
+	case strings.Contains(msg, "database is locked"), strings.Contains(msg, "database table is locked"):
+		// SQLITE_BUSY after busy_timeout, there is no separate deadlock detection
+		return errorTimeout
+	case strings.Contains(msg, "UNIQUE constraint failed"):
+		return errorUniqueViolation
+	}
+	return ""
+}
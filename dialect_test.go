@@ -0,0 +1,93 @@
+package dbr
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/lianchengwu/dbr/dialect"
+	"github.com/stretchr/testify/assert"
+)
+
+// fullDialect has the optional interfaces implemented by the dialects of the dialect package
+type fullDialect interface {
+	Dialect
+	CondDialect
+	DurationDialect
+	InsertIgnoreDialect
+	ForUpdateDialect
+	ConsistencyDialect
+	TupleInDialect
+	AggregateFilterDialect
+	WindowDialect
+	ReturningDialect
+	UpdateFromDialect
+	MultiTableUpdateDialect
+	UnnestDialect
+	MergeDialect
+	CreateTableAsDialect
+	TempTableDialect
+	JSONDialect
+	UnaccentDialect
+	LikeEscapeDialect
+	IndexHintDialect
+	TableSampleDialect
+	ResetDialect
+	SavepointDialect
+	ConstraintsDialect
+	CastDialect
+	RandomDialect
+	DistinctFromDialect
+	ErrorClassDialect
+}
+
+var (
+	_ fullDialect = dialect.ClickHouse
+	_ fullDialect = dialect.MySQL
+	_ fullDialect = dialect.Oracle
+	_ fullDialect = dialect.PostgreSQL
+	_ fullDialect = dialect.SQLite3
+	_ fullDialect = dialect.SQLite3Version(3, 35)
+)
+
+// baseOnlyDialect has only the methods of Dialect, like dialects implemented outside of dbr
+type baseOnlyDialect struct {
+	Dialect
+}
+
+func TestDialectWithoutOptionalInterfaces(t *testing.T) {
+	d := baseOnlyDialect{dialect.PostgreSQL}
+	for _, test := range []struct {
+		builder Builder
+		query   string
+		err     error
+	}{
+		// fallbacks
+		{builder: Select("a").From("t").Where(Eq("b", []int{})).ForUpdate(), query: `SELECT a FROM t WHERE (FALSE) FOR UPDATE`},
+		{builder: Select("a").From("t").Where(InTuple([]string{"a", "b"}, [][]interface{}{{1, 2}})), query: `SELECT a FROM t WHERE ((("a" = 1) AND ("b" = 2)))`},
+		{builder: AggregateFilter("COUNT", "*", Eq("a", 1)), query: `COUNT(CASE WHEN "a" = 1 THEN 1 END)`},
+		{builder: Like("a", "x%"), query: `"a" LIKE 'x%'`},
+		{builder: InsertInto("t").Columns("a").Values(1).Cast("a", "jsonb"), query: `INSERT INTO "t" ("a") VALUES (1)`},
+		// unsupported features
+		{builder: InsertInto("t").Columns("a").Values(1).Returning("a"), err: ErrNotSupported},
+		{builder: InsertInto("t").Columns("a").Values(1).Ignore(), err: ErrNotSupported},
+		{builder: Update("t").Set("a", 1).From("u"), err: ErrNotSupported},
+		{builder: Merge("t").Using("u", "s").On("t.id = s.id").WhenMatchedDelete(), err: ErrNotSupported},
+		{builder: CreateTableAs("t", Select("a").From("u")), err: ErrNotSupported},
+		{builder: JSONAgg("a"), err: ErrNotSupported},
+		{builder: JSONObject("a"), err: ErrNotSupported},
+		{builder: Unaccent("a", "x"), err: ErrNotSupported},
+		{builder: IsDistinctFrom("a", 1), err: ErrNotSupported},
+		{builder: Unnest([]int{1}, "int", "x", "id"), err: ErrNotSupported},
+		{builder: Select("a").From("t").OrderByRandom(), err: ErrNotSupported},
+		{builder: Select("a").From("t").TableSample(TableSampleSystem, 10), err: ErrNotSupported},
+	} {
+		query, err := InterpolateForDialect("?", []interface{}{BuildFunc(test.builder.Build)}, d)
+		assert.Equal(t, test.err, err)
+		assert.Equal(t, test.query, query)
+	}
+	assert.Equal(t, "", ClassifyError(d, errors.New("deadlock detected")))
+
+	// wrappers do not hide the optional interfaces of the wrapped dialect
+	err := JSONAgg("a").Build(WithPlaceholder(MinimalParentheses(dialect.PostgreSQL), func(n int) string { return "?" }), NewBuffer())
+	assert.NoError(t, err)
+}
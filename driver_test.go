@@ -0,0 +1,117 @@
+package dbr
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"sync"
+)
+
+// fakeStmt is a statement received by fakeDriver
+type fakeStmt struct {
+	query string
+	args  []interface{}
+}
+
+// fakeDriver records executed statements, its connections accept values of any type
+// like drivers implementing driver.NamedValueChecker
+type fakeDriver struct {
+	mu    sync.Mutex
+	stmts []fakeStmt
+	// columns and rows are returned for every query
+	columns []string
+	rows    [][]driver.Value
+}
+
+func (d *fakeDriver) Connect(context.Context) (driver.Conn, error) {
+	return &fakeConn{d: d}, nil
+}
+
+func (d *fakeDriver) Driver() driver.Driver {
+	return d
+}
+
+func (d *fakeDriver) Open(string) (driver.Conn, error) {
+	return &fakeConn{d: d}, nil
+}
+
+func (d *fakeDriver) record(query string, args []driver.NamedValue) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	var v []interface{}
+	for _, arg := range args {
+		v = append(v, arg.Value)
+	}
+	d.stmts = append(d.stmts, fakeStmt{query: query, args: v})
+}
+
+func (d *fakeDriver) statements() []fakeStmt {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]fakeStmt(nil), d.stmts...)
+}
+
+type fakeConn struct {
+	d *fakeDriver
+}
+
+func (c *fakeConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("fake: prepare is not supported")
+}
+
+func (c *fakeConn) Close() error {
+	return nil
+}
+
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	return fakeTx{}, nil
+}
+
+func (c *fakeConn) CheckNamedValue(*driver.NamedValue) error {
+	return nil
+}
+
+func (c *fakeConn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	c.d.record(query, args)
+	return driver.RowsAffected(0), nil
+}
+
+func (c *fakeConn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	c.d.record(query, args)
+	return &fakeRows{columns: c.d.columns, rows: c.d.rows}, nil
+}
+
+type fakeTx struct{}
+
+func (fakeTx) Commit() error   { return nil }
+func (fakeTx) Rollback() error { return nil }
+
+type fakeRows struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+func (r *fakeRows) Columns() []string {
+	return r.columns
+}
+
+func (r *fakeRows) Close() error {
+	return nil
+}
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
+
+func newFakeSession(d Dialect) (*Session, *fakeDriver) {
+	fake := &fakeDriver{}
+	conn := &Connection{DB: sql.OpenDB(fake), Dialect: d, EventReceiver: nullReceiver}
+	return conn.NewSession(nil), fake
+}
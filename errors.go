@@ -1,17 +1,80 @@
 package dbr
 
-import "errors"
+import (
+	"context"
+	"errors"
+	"fmt"
+)
 
 // package errors
 var (
-	ErrNotFound             = errors.New("dbr: not found")
-	ErrNotSupported         = errors.New("dbr: not supported")
-	ErrTableNotSpecified    = errors.New("dbr: table not specified")
-	ErrColumnNotSpecified   = errors.New("dbr: column not specified")
-	ErrInvalidPointer       = errors.New("dbr: attempt to load into an invalid pointer")
-	ErrPlaceholderCount     = errors.New("dbr: wrong placeholder count")
-	ErrInvalidSliceLength   = errors.New("dbr: length of slice is 0. length must be >= 1")
-	ErrCantConvertToTime    = errors.New("dbr: can't convert to time.Time")
-	ErrInvalidTimestring    = errors.New("dbr: invalid time string")
-	ErrPrewhereNotSupported = errors.New("dbr: PREWHERE statement is not supported")
+	ErrNotFound                  = errors.New("dbr: not found")
+	ErrNotSupported              = errors.New("dbr: not supported")
+	ErrTableNotSpecified         = errors.New("dbr: table not specified")
+	ErrColumnNotSpecified        = errors.New("dbr: column not specified")
+	ErrCondNotSpecified          = errors.New("dbr: condition not specified")
+	ErrMissingWhere              = errors.New("dbr: WHERE is required, use AllRows to affect all rows")
+	ErrInvalidPointer            = errors.New("dbr: attempt to load into an invalid pointer")
+	ErrNotStruct                 = errors.New("dbr: value is not a struct")
+	ErrPlaceholderCount          = errors.New("dbr: wrong placeholder count")
+	ErrNulByte                   = errors.New("dbr: string contains NUL byte")
+	ErrValueTooLarge             = errors.New("dbr: value exceeds max size")
+	ErrDestinationCount          = errors.New("dbr: wrong destination count")
+	ErrReturningCount            = errors.New("dbr: returned row count does not match records")
+	ErrColumnMismatch            = errors.New("dbr: column does not match a struct field")
+	ErrRecordColumns             = errors.New("dbr: records have different columns")
+	ErrInvalidSliceLength        = errors.New("dbr: length of slice is 0. length must be >= 1")
+	ErrTupleLength               = errors.New("dbr: length of tuple does not match column count")
+	ErrStreamClosed              = errors.New("dbr: stream is closed")
+	ErrCantConvertToTime         = errors.New("dbr: can't convert to time.Time")
+	ErrInvalidTimestring         = errors.New("dbr: invalid time string")
+	ErrPrewhereNotSupported      = errors.New("dbr: PREWHERE statement is not supported")
+	ErrIndexHintNotSupported     = errors.New("dbr: index hint is not supported")
+	ErrInvalidTableSample        = errors.New("dbr: invalid table sample method or percent")
+	ErrOrderNotAllowed           = errors.New("dbr: order field is not allowed")
+	ErrInvalidOrder              = errors.New("dbr: order must be a string or a Builder")
+	ErrInvalidDirection          = errors.New("dbr: invalid order direction")
+	ErrTxCommitted               = errors.New("dbr: transaction has already been committed")
+	ErrTxRolledBack              = errors.New("dbr: transaction has already been rolled back")
+	ErrInvalidSavepoint          = errors.New("dbr: invalid savepoint name")
+	ErrCrypterNotSpecified       = errors.New("dbr: crypt field requires Session.Crypter")
+	ErrInvalidCryptField         = errors.New("dbr: crypt field must be a string or []byte")
+	ErrTableResolverNotSpecified = errors.New("dbr: sharded table requires Session.TableResolver")
+	ErrInvalidCacheValue         = errors.New("dbr: value can not be encoded in a cache key")
 )
+
+// PlaceholderCountError is returned by Build if the number of placeholders
+// does not match the number of values, it is a ErrPlaceholderCount with details
+type PlaceholderCountError struct {
+	Query        string
+	Placeholders int
+	Values       int
+}
+
+func (e *PlaceholderCountError) Error() string {
+	return fmt.Sprintf("%s: %d placeholders and %d values in %q", ErrPlaceholderCount, e.Placeholders, e.Values, e.Query)
+}
+
+// Unwrap returns ErrPlaceholderCount
+func (e *PlaceholderCountError) Unwrap() error {
+	return ErrPlaceholderCount
+}
+
+// error classes reported to ErrorClassReceiver
+const (
+	ErrorClassDeadlock        = "deadlock"
+	ErrorClassTimeout         = "timeout"
+	ErrorClassUniqueViolation = "unique_violation"
+)
+
+// ClassifyError returns the class of a query error in dialect d,
+// e.g. ErrorClassDeadlock, or an empty string if the error is not classified
+func ClassifyError(d Dialect, err error) string {
+	if err == context.DeadlineExceeded {
+		return ErrorClassTimeout
+	}
+	if c, ok := baseDialect(d).(ErrorClassDialect); ok {
+		return c.ClassifyError(err)
+	}
+	return ""
+}
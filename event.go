@@ -1,5 +1,7 @@
 package dbr
 
+import "context"
+
 // EventReceiver gets events from dbr methods for logging purposes
 type EventReceiver interface {
 	Event(eventName string)
@@ -10,8 +12,67 @@ type EventReceiver interface {
 	TimingKv(eventName string, nanoseconds int64, kvs map[string]string)
 }
 
+// ErrorClassReceiver is an optional interface of EventReceiver.
+// It receives failed Exec and Load calls along with the class of the error (see ClassifyError),
+// e.g. to count deadlocks. kvs contains the query as "sql".
+type ErrorClassReceiver interface {
+	EventErrClass(eventName string, class string, err error, kvs map[string]string)
+}
+
+// errorClassReceiver returns log as ErrorClassReceiver,
+// receivers of sessions and transactions are looked up in their parents
+func errorClassReceiver(log EventReceiver) (ErrorClassReceiver, bool) {
+	for {
+		switch l := log.(type) {
+		case ErrorClassReceiver:
+			return l, true
+		case *Tx:
+			log = l.EventReceiver
+		case *Session:
+			log = l.EventReceiver
+		default:
+			return nil, false
+		}
+	}
+}
+
 type kvs map[string]string
 
+type eventTagsKey struct{}
+
+// WithEventTags returns a copy of ctx carrying request-scoped tags (e.g. request id).
+// Queries of a session created with such context add the tags to key/value data of their events.
+// Tags of the parent context are kept unless overridden.
+func WithEventTags(ctx context.Context, tags map[string]string) context.Context {
+	m := make(map[string]string)
+	for k, v := range EventTags(ctx) {
+		m[k] = v
+	}
+	for k, v := range tags {
+		m[k] = v
+	}
+	return context.WithValue(ctx, eventTagsKey{}, m)
+}
+
+// EventTags returns tags stored in ctx by WithEventTags
+func EventTags(ctx context.Context) map[string]string {
+	if ctx == nil {
+		return nil
+	}
+	m, _ := ctx.Value(eventTagsKey{}).(map[string]string)
+	return m
+}
+
+// eventKvs adds tags of ctx to m, existing keys are not overridden
+func eventKvs(ctx context.Context, m kvs) kvs {
+	for k, v := range EventTags(ctx) {
+		if _, ok := m[k]; !ok {
+			m[k] = v
+		}
+	}
+	return m
+}
+
 var nullReceiver = &NullEventReceiver{}
 
 // NullEventReceiver is a sentinel EventReceiver; use it if the caller doesn't supply one
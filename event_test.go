@@ -0,0 +1,98 @@
+package dbr
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lianchengwu/dbr/dialect"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventTags(t *testing.T) {
+	ctx := WithEventTags(context.Background(), map[string]string{"request_id": "r1", "user_id": "u1"})
+	ctx = WithEventTags(ctx, map[string]string{"user_id": "u2"})
+	assert.Equal(t, map[string]string{"request_id": "r1", "user_id": "u2"}, EventTags(ctx))
+	assert.Nil(t, EventTags(context.Background()))
+
+	sess, dbmock := newSessionMock()
+	recv := &testEventReceiver{}
+	sess = sess.Connection.NewSessionContext(ctx, recv)
+
+	dbmock.ExpectQuery("SELECT a FROM table").WillReturnRows(sqlmock.NewRows([]string{"a"}).AddRow(1))
+	dbmock.ExpectBegin()
+	dbmock.ExpectExec("UPDATE `table`").WillReturnError(assert.AnError)
+	dbmock.ExpectRollback()
+
+	var a int
+	err := sess.Select("a").From("table").LoadValue(&a)
+	assert.NoError(t, err)
+	e, ok := recv.find("dbr.select")
+	if assert.True(t, ok) {
+		assert.Equal(t, "r1", e.kvs["request_id"])
+		assert.Equal(t, "u2", e.kvs["user_id"])
+		assert.Equal(t, "SELECT a FROM table", e.kvs["sql"])
+	}
+
+	tx, err := sess.Begin()
+	assert.NoError(t, err)
+	_, err = tx.Update("table").Set("a", 1).Exec()
+	assert.Equal(t, assert.AnError, err)
+	e, ok = recv.find("dbr.exec.exec")
+	if assert.True(t, ok) {
+		assert.Equal(t, "r1", e.kvs["request_id"])
+		assert.Equal(t, assert.AnError, e.err)
+	}
+	assert.NoError(t, tx.Rollback())
+	assert.NoError(t, dbmock.ExpectationsWereMet())
+}
+
+// testErrorClassReceiver records classes of failed queries
+type testErrorClassReceiver struct {
+	testEventReceiver
+
+	class []string
+	sql   []string
+}
+
+func (r *testErrorClassReceiver) EventErrClass(eventName string, class string, err error, kvs map[string]string) {
+	r.class = append(r.class, class)
+	r.sql = append(r.sql, kvs["sql"])
+}
+
+func TestEventErrClass(t *testing.T) {
+	sess, dbmock := newSessionMock()
+	recv := &testErrorClassReceiver{}
+	sess = sess.NewSession(recv)
+
+	deadlock := errors.New("Error 1213: Deadlock found when trying to get lock; try restarting transaction")
+	dbmock.ExpectBegin()
+	dbmock.ExpectExec("UPDATE `table`").WillReturnError(deadlock)
+	dbmock.ExpectRollback()
+	dbmock.ExpectQuery("SELECT a FROM table").WillReturnError(errors.New("Error 1062: Duplicate entry '1' for key 'PRIMARY'"))
+	dbmock.ExpectQuery("SELECT a FROM table").WillReturnError(assert.AnError)
+
+	tx, err := sess.Begin()
+	assert.NoError(t, err)
+	_, err = tx.Update("table").Set("a", 1).Exec()
+	assert.Equal(t, deadlock, err)
+	assert.NoError(t, tx.Rollback())
+
+	var a []int
+	_, err = sess.Select("a").From("table").Load(&a)
+	assert.Error(t, err)
+	_, err = sess.Select("a").From("table").Load(&a)
+	assert.Equal(t, assert.AnError, err)
+
+	assert.Equal(t, []string{ErrorClassDeadlock, ErrorClassUniqueViolation, ""}, recv.class)
+	assert.Equal(t, []string{"UPDATE `table` SET `a` = 1", "SELECT a FROM table", "SELECT a FROM table"}, recv.sql)
+	// EventReceiver is still notified
+	e, ok := recv.find("dbr.exec.exec")
+	if assert.True(t, ok) {
+		assert.Equal(t, deadlock, e.err)
+	}
+	assert.NoError(t, dbmock.ExpectationsWereMet())
+
+	assert.Equal(t, ErrorClassTimeout, ClassifyError(dialect.PostgreSQL, context.DeadlineExceeded))
+}
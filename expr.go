@@ -11,6 +11,10 @@ func Expr(query string, value ...interface{}) Builder {
 }
 
 func (raw *raw) Build(_ Dialect, buf Buffer) error {
+	err := checkPlaceholders(raw.Query, raw.Value)
+	if err != nil {
+		return err
+	}
 	buf.WriteString(raw.Query)
 	buf.WriteValue(raw.Value...)
 	return nil
@@ -0,0 +1,82 @@
+package dbr
+
+import "reflect"
+
+// FindOrCreate inserts record into table unless a row with the same keyColumns exists,
+// and loads the new or the existing row into dest, a pointer to a struct.
+// It returns true if the row was created.
+// It relies on a unique constraint on keyColumns to be safe against concurrent inserts:
+// the insert ignores the conflict instead of failing, see InsertStmt.Ignore.
+// Dialects supporting RETURNING (PostgreSQL) take one round trip if the row is created
+// (e.g. INSERT ... ON CONFLICT (keyColumns) DO NOTHING RETURNING *) and two otherwise,
+// MySQL and SQLite always take two (INSERT IGNORE, then SELECT by keyColumns).
+// Other dialects return ErrNotSupported.
+func (sess *Session) FindOrCreate(table string, keyColumns []string, record, dest interface{}) (bool, error) {
+	return findOrCreate(sess, sess, sess.Dialect, table, keyColumns, record, dest)
+}
+
+// FindOrCreate inserts record unless it exists and loads the row into dest, see Session.FindOrCreate
+func (tx *Tx) FindOrCreate(table string, keyColumns []string, record, dest interface{}) (bool, error) {
+	return findOrCreate(tx, tx, tx.Dialect, table, keyColumns, record, dest)
+}
+
+func findOrCreate(runner runner, log EventReceiver, d Dialect, table string, keyColumns []string, record, dest interface{}) (bool, error) {
+	if len(keyColumns) == 0 {
+		return false, ErrColumnNotSpecified
+	}
+	v := reflect.Indirect(reflect.ValueOf(record))
+	if v.Kind() != reflect.Struct {
+		return false, ErrNotStruct
+	}
+	m := structMap(v.Type())
+	key := make([]Builder, len(keyColumns))
+	for i, column := range keyColumns {
+		index, ok := m[column]
+		if !ok {
+			return false, ErrColumnNotSpecified
+		}
+		key[i] = Eq(column, v.FieldByIndex(index).Interface())
+	}
+
+	stmt := createInsertStmt(table)
+	stmt.Record(record)
+	stmt.Ignore(keyColumns...)
+
+	created := false
+	if supportsReturning(d) {
+		stmt.Returning("*")
+		count, err := query(runner, log, stmt, d, dest)
+		if err != nil {
+			return false, err
+		}
+		if count > 0 {
+			return true, nil
+		}
+	} else {
+		result, err := exec(runner, log, stmt, d)
+		if err != nil {
+			return false, err
+		}
+		count, err := result.RowsAffected()
+		if err != nil {
+			return false, err
+		}
+		created = count > 0
+	}
+
+	find := createSelectStmt([]interface{}{"*"})
+	find.From(table)
+	for _, cond := range key {
+		find.Where(cond)
+	}
+	// a SelectStmt value is rendered as a subquery
+	count, err := query(runner, log, BuildFunc(find.Build), d, dest)
+	if err != nil {
+		return false, err
+	}
+	if count == 0 {
+		// the conflicting row was deleted in the meantime
+		return false, ErrNotFound
+	}
+	return created, nil
+}
@@ -0,0 +1,122 @@
+package dbr
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lianchengwu/dbr/dialect"
+	"github.com/stretchr/testify/assert"
+)
+
+type findOrCreateKey struct {
+	KeyValue string `db:"key_value"`
+	ValValue string `db:"val_value"`
+}
+
+func TestFindOrCreate(t *testing.T) {
+	for _, sess := range testSession {
+		if sess.Dialect == dialect.ClickHouse {
+			continue
+		}
+		key := fmt.Sprintf("find_or_create_%d", nextID())
+
+		var row findOrCreateKey
+		created, err := sess.FindOrCreate("dbr_keys", []string{"key_value"}, &findOrCreateKey{KeyValue: key, ValValue: "new"}, &row)
+		assert.NoError(t, err)
+		assert.True(t, created)
+		assert.Equal(t, findOrCreateKey{KeyValue: key, ValValue: "new"}, row)
+
+		// the existing row is loaded, not the record
+		row = findOrCreateKey{}
+		created, err = sess.FindOrCreate("dbr_keys", []string{"key_value"}, &findOrCreateKey{KeyValue: key, ValValue: "other"}, &row)
+		assert.NoError(t, err)
+		assert.False(t, created)
+		assert.Equal(t, findOrCreateKey{KeyValue: key, ValValue: "new"}, row)
+	}
+}
+
+func TestFindOrCreateMySQL(t *testing.T) {
+	sess, dbmock := newSessionMock()
+	insert := regexp.QuoteMeta("INSERT IGNORE INTO `dbr_keys` (`key_value`,`val_value`) VALUES ('a','new')")
+	find := regexp.QuoteMeta("SELECT * FROM dbr_keys WHERE (`key_value` = 'a')")
+	rows := func(val string) *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"key_value", "val_value"}).AddRow("a", val)
+	}
+	dbmock.ExpectExec(insert).WillReturnResult(sqlmock.NewResult(0, 1))
+	dbmock.ExpectQuery(find).WillReturnRows(rows("new"))
+	dbmock.ExpectExec(insert).WillReturnResult(sqlmock.NewResult(0, 0))
+	dbmock.ExpectQuery(find).WillReturnRows(rows("old"))
+
+	for _, test := range []struct {
+		created bool
+		row     findOrCreateKey
+	}{
+		{created: true, row: findOrCreateKey{KeyValue: "a", ValValue: "new"}},
+		{created: false, row: findOrCreateKey{KeyValue: "a", ValValue: "old"}},
+	} {
+		var row findOrCreateKey
+		created, err := sess.FindOrCreate("dbr_keys", []string{"key_value"}, findOrCreateKey{KeyValue: "a", ValValue: "new"}, &row)
+		assert.NoError(t, err)
+		assert.Equal(t, test.created, created)
+		assert.Equal(t, test.row, row)
+	}
+	assert.NoError(t, dbmock.ExpectationsWereMet())
+
+	_, err := sess.FindOrCreate("dbr_keys", []string{"missing"}, findOrCreateKey{}, &findOrCreateKey{})
+	assert.Equal(t, ErrColumnNotSpecified, err)
+}
+
+func TestFindOrCreatePostgreSQL(t *testing.T) {
+	db, dbmock, err := sqlmock.New()
+	assert.NoError(t, err)
+	conn := Connection{DB: db, Dialect: dialect.PostgreSQL, EventReceiver: nullReceiver}
+	sess := conn.NewSession(nil)
+
+	insert := regexp.QuoteMeta(`INSERT INTO "dbr_keys" ("key_value","val_value") VALUES ('a','new') ON CONFLICT ("key_value") DO NOTHING RETURNING *`)
+	columns := []string{"key_value", "val_value"}
+	// created in one round trip
+	dbmock.ExpectQuery(insert).WillReturnRows(sqlmock.NewRows(columns).AddRow("a", "new"))
+	// nothing is returned on conflict
+	dbmock.ExpectQuery(insert).WillReturnRows(sqlmock.NewRows(columns))
+	dbmock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM dbr_keys WHERE ("key_value" = 'a')`)).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow("a", "old"))
+
+	var row findOrCreateKey
+	created, err := sess.FindOrCreate("dbr_keys", []string{"key_value"}, findOrCreateKey{KeyValue: "a", ValValue: "new"}, &row)
+	assert.NoError(t, err)
+	assert.True(t, created)
+	assert.Equal(t, findOrCreateKey{KeyValue: "a", ValValue: "new"}, row)
+
+	created, err = sess.FindOrCreate("dbr_keys", []string{"key_value"}, findOrCreateKey{KeyValue: "a", ValValue: "new"}, &row)
+	assert.NoError(t, err)
+	assert.False(t, created)
+	assert.Equal(t, findOrCreateKey{KeyValue: "a", ValValue: "old"}, row)
+	assert.NoError(t, dbmock.ExpectationsWereMet())
+}
+
+func TestInsertStmtIgnore(t *testing.T) {
+	for _, test := range []struct {
+		dialect Dialect
+		query   string
+		err     error
+	}{
+		{dialect: dialect.MySQL, query: "INSERT IGNORE INTO `t` (`a`) VALUES (?)"},
+		{dialect: dialect.PostgreSQL, query: `INSERT INTO "t" ("a") VALUES (?) ON CONFLICT DO NOTHING`},
+		{dialect: dialect.SQLite3, query: `INSERT OR IGNORE INTO "t" ("a") VALUES (?)`},
+		{dialect: dialect.ClickHouse, err: ErrNotSupported},
+	} {
+		buf := NewBuffer()
+		err := InsertInto("t").Columns("a").Values(1).Ignore().Build(test.dialect, buf)
+		assert.Equal(t, test.err, err)
+		if err == nil {
+			assert.Equal(t, test.query, buf.String())
+		}
+	}
+
+	buf := NewBuffer()
+	err := InsertInto("t").Columns("a", "b").Values(1, 2).Ignore("a", "b").Build(dialect.PostgreSQL, buf)
+	assert.NoError(t, err)
+	assert.Equal(t, `INSERT INTO "t" ("a","b") VALUES (?,?) ON CONFLICT ("a","b") DO NOTHING`, buf.String())
+}
@@ -0,0 +1,219 @@
+package dbr
+
+import (
+	"strings"
+
+	"github.com/lianchengwu/dbr/dialect"
+)
+
+// KeywordCase is the case of SQL keywords written by Format
+type KeywordCase int
+
+// Case of keywords
+const (
+	// KeywordPreserve keeps keywords as they are built, i.e. uppercase in builders and as is in raw SQL
+	KeywordPreserve KeywordCase = iota
+	// KeywordUpper writes keywords in uppercase, e.g. SELECT
+	KeywordUpper
+	// KeywordLower writes keywords in lowercase, e.g. select
+	KeywordLower
+)
+
+// Format is the style of queries, e.g. for legible logs, see Session.Format.
+// The zero Format keeps queries as they are built.
+type Format struct {
+	// Case is the case of SQL keywords
+	Case KeywordCase
+	// Newline starts the clauses of the top level statement, e.g. FROM, JOIN, WHERE and ORDER BY, on a new line
+	Newline bool
+}
+
+// Apply returns query of dialect d in the style of f. String literals, quoted identifiers
+// and comments are unchanged, as well as qualified names (e.g. t.order), so only the case of keywords and
+// whitespace between tokens change. Unquoted identifiers which are keywords (e.g. a column named key)
+// are keywords too, quote them (e.g. with I) if their case matters.
+// Case is ignored in ClickHouse, whose identifiers are case-sensitive.
+func (f Format) Apply(d Dialect, query string) string {
+	if baseDialect(d) == Dialect(dialect.ClickHouse) {
+		f.Case = KeywordPreserve
+	}
+	if f == (Format{}) {
+		return query
+	}
+	// MySQL and ClickHouse escape quotes of string literals with backslash
+	backslash := d.EncodeString(`\`) != `'\'`
+
+	buf := make([]byte, 0, len(query)+16)
+	depth := 0
+	// chain is the start of clause words before a keyword which breaks the line, e.g. LEFT of LEFT JOIN
+	chain := -1
+	prev := ""
+	for i := 0; i < len(query); {
+		c := query[i]
+		end := i + 1
+		word := ""
+		switch {
+		case c == '\'' || c == '"':
+			end = quoteEnd(query, i, backslash)
+		case c == '`':
+			end = quoteEnd(query, i, false)
+		case c == '-' && strings.HasPrefix(query[i:], "--"):
+			end = strings.IndexByte(query[i:], '\n')
+			if end == -1 {
+				end = len(query)
+			} else {
+				end += i
+			}
+		case c == '/' && strings.HasPrefix(query[i:], "/*"):
+			end = strings.Index(query[i+2:], "*/")
+			if end == -1 {
+				end = len(query)
+			} else {
+				end += i + 4
+			}
+		case c == '$':
+			end = dollarQuoteEnd(query, i)
+		case isWordByte(c):
+			for end < len(query) && (isWordByte(query[end]) || query[end] == '$') {
+				end++
+			}
+			word = query[i:end]
+			if (word == "E" || word == "e") && end < len(query) && query[end] == '\'' {
+				// escape string constant of PostgreSQL, e.g. E'\n'
+				end = quoteEnd(query, end, true)
+				word = ""
+			}
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+		}
+
+		if word == "" {
+			buf = append(buf, query[i:end]...)
+			if c != ' ' && c != '\t' && c != '\n' {
+				chain = -1
+				prev = ""
+			}
+			i = end
+			continue
+		}
+
+		upper := strings.ToUpper(word)
+		qualified := (i > 0 && strings.IndexByte(".:@", query[i-1]) != -1) ||
+			(end < len(query) && query[end] == '.')
+		if qualified || !formatKeyword[upper] || word[0] >= '0' && word[0] <= '9' {
+			buf = append(buf, word...)
+			chain = -1
+			prev = upper
+			i = end
+			continue
+		}
+
+		if f.Newline && depth == 0 {
+			brk := -1
+			switch {
+			case upper == "FROM" && (prev == "DELETE" || prev == "DISTINCT"):
+			case formatClause[upper] || formatChainEnd[upper] && chain != -1:
+				brk = len(buf)
+				if chain != -1 {
+					brk = chain
+				}
+			}
+			if brk > 0 && buf[brk-1] == ' ' {
+				buf[brk-1] = '\n'
+			}
+			if !formatChain[upper] {
+				chain = -1
+			} else if chain == -1 {
+				chain = len(buf)
+			}
+		}
+
+		switch f.Case {
+		case KeywordUpper:
+			buf = append(buf, upper...)
+		case KeywordLower:
+			buf = append(buf, strings.ToLower(word)...)
+		default:
+			buf = append(buf, word...)
+		}
+		prev = upper
+		i = end
+	}
+	return string(buf)
+}
+
+func isWordByte(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c >= 0x80
+}
+
+// quoteEnd returns the index after the literal or identifier quoted with query[start],
+// doubled quotes are escaped, and backslash escapes the next byte if backslash is true
+func quoteEnd(query string, start int, backslash bool) int {
+	quote := query[start]
+	for i := start + 1; i < len(query); i++ {
+		switch query[i] {
+		case '\\':
+			if backslash {
+				i++
+			}
+		case quote:
+			if i+1 < len(query) && query[i+1] == quote {
+				i++
+				continue
+			}
+			return i + 1
+		}
+	}
+	return len(query)
+}
+
+// dollarQuoteEnd returns the index after the dollar-quoted string constant of PostgreSQL at start,
+// e.g. $$it's$$ or $body$...$body$, or start+1 if it is not one (e.g. placeholder $1)
+func dollarQuoteEnd(query string, start int) int {
+	tagEnd := start + 1
+	for tagEnd < len(query) && isWordByte(query[tagEnd]) {
+		tagEnd++
+	}
+	if tagEnd == len(query) || query[tagEnd] != '$' || query[start+1] >= '0' && query[start+1] <= '9' {
+		return start + 1
+	}
+	tag := query[start : tagEnd+1]
+	end := strings.Index(query[tagEnd+1:], tag)
+	if end == -1 {
+		return start + 1
+	}
+	return tagEnd + 1 + end + len(tag)
+}
+
+// formatKeyword is the set of keywords changed by Format.Case
+var formatKeyword = stringSet(
+	"ALL", "AND", "ANY", "AS", "ASC", "BETWEEN", "BY", "CASE", "CONFLICT", "CROSS", "DELETE", "DESC", "DISTINCT",
+	"DO", "DUPLICATE", "ELSE", "END", "ESCAPE", "EXCEPT", "EXISTS", "FALSE", "FETCH", "FIRST", "FOR", "FROM",
+	"FULL", "GROUP", "HAVING", "IGNORE", "ILIKE", "IN", "INNER", "INSERT", "INTERSECT", "INTERVAL", "INTO", "IS",
+	"JOIN", "KEY", "LEFT", "LIKE", "LIMIT", "LOCKED", "MATCHED", "MERGE", "NATURAL", "NEXT", "NOT", "NOTHING",
+	"NOWAIT", "NULL", "OFFSET", "ON", "ONLY", "OR", "ORDER", "OUTER", "OVER", "PARTITION", "RECURSIVE",
+	"RETURNING", "RIGHT", "ROWS", "SELECT", "SET", "SHARE", "SKIP", "THEN", "TRUE", "UNION", "UPDATE", "USING",
+	"VALUES", "WHEN", "WHERE", "WITH",
+)
+
+// formatClause is the set of keywords starting a new line with Format.Newline
+var formatClause = stringSet(
+	"FROM", "JOIN", "WHERE", "HAVING", "LIMIT", "UNION", "INTERSECT", "EXCEPT", "VALUES", "SET", "RETURNING",
+)
+
+// formatChain is the set of keywords which start a clause along with the next keyword,
+// e.g. ORDER BY, LEFT OUTER JOIN, ON CONFLICT and FOR UPDATE
+var formatChain = stringSet("LEFT", "RIGHT", "FULL", "INNER", "CROSS", "NATURAL", "OUTER", "GROUP", "ORDER", "ON", "FOR")
+
+// formatChainEnd is the set of keywords which start a new line after formatChain keywords
+var formatChainEnd = stringSet("BY", "CONFLICT", "DUPLICATE", "UPDATE", "SHARE")
+
+func stringSet(s ...string) map[string]bool {
+	m := make(map[string]bool, len(s))
+	for _, v := range s {
+		m[v] = true
+	}
+	return m
+}
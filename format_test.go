@@ -0,0 +1,97 @@
+package dbr
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/lianchengwu/dbr/dialect"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormat(t *testing.T) {
+	for _, test := range []struct {
+		format Format
+		d      Dialect
+		query  string
+		want   string
+	}{
+		{
+			format: Format{Case: KeywordLower},
+			d:      dialect.MySQL,
+			query:  "SELECT t.order, `FROM` FROM t WHERE (a = 'it\\'s FROM' AND b IS NULL) ORDER BY id DESC LIMIT 10",
+			want:   "select t.order, `FROM` from t where (a = 'it\\'s FROM' and b is null) order by id desc limit 10",
+		},
+		{
+			format: Format{Case: KeywordUpper},
+			d:      dialect.PostgreSQL,
+			query:  `select "select", 'a\' from t -- from comment` + "\n" + `where id in ($1, $2) and body = $$it's and$$`,
+			want:   `SELECT "select", 'a\' FROM t -- from comment` + "\n" + `WHERE id IN ($1, $2) AND body = $$it's and$$`,
+		},
+		{
+			format: Format{Newline: true},
+			d:      dialect.MySQL,
+			query: "SELECT a.id, COUNT(*) OVER (PARTITION BY a.id ORDER BY b.id) FROM a LEFT OUTER JOIN b ON b.id = a.id " +
+				"WHERE (a.id IN (SELECT id FROM c WHERE x)) GROUP BY a.id ORDER BY a.id LIMIT 10 OFFSET 5 FOR UPDATE",
+			want: "SELECT a.id, COUNT(*) OVER (PARTITION BY a.id ORDER BY b.id)\nFROM a\nLEFT OUTER JOIN b ON b.id = a.id" +
+				"\nWHERE (a.id IN (SELECT id FROM c WHERE x))\nGROUP BY a.id\nORDER BY a.id\nLIMIT 10 OFFSET 5\nFOR UPDATE",
+		},
+		{
+			format: Format{Case: KeywordLower, Newline: true},
+			d:      dialect.PostgreSQL,
+			query: `INSERT INTO "t" ("id","name") VALUES (1,'a') ON CONFLICT ("id") DO UPDATE SET "name" = EXCLUDED."name" ` +
+				`RETURNING "id"`,
+			want: "insert into \"t\" (\"id\",\"name\")\nvalues (1,'a')\non conflict (\"id\") do update\nset \"name\" = EXCLUDED.\"name\"" +
+				"\nreturning \"id\"",
+		},
+		{
+			format: Format{Newline: true},
+			d:      dialect.PostgreSQL,
+			query:  `DELETE FROM "t" WHERE ("a" IS DISTINCT FROM "b")`,
+			want:   "DELETE FROM \"t\"\nWHERE (\"a\" IS DISTINCT FROM \"b\")",
+		},
+		{
+			// identifiers of ClickHouse are case-sensitive, e.g. a column named key
+			format: Format{Case: KeywordUpper, Newline: true},
+			d:      dialect.ClickHouse,
+			query:  "select key FROM t where key = 1",
+			want:   "select key\nFROM t\nwhere key = 1",
+		},
+		{
+			format: Format{},
+			d:      dialect.MySQL,
+			query:  "select 1",
+			want:   "select 1",
+		},
+	} {
+		assert.Equal(t, test.want, test.format.Apply(test.d, test.query))
+	}
+}
+
+func TestSessionFormat(t *testing.T) {
+	sess, fake := newFakeSession(dialect.MySQL)
+	sess.Format = Format{Case: KeywordLower, Newline: true}
+	var ids []int64
+	_, err := sess.Select("id").From("users").Where(Eq("name", "SELECT")).OrderBy("id").Load(&ids)
+	assert.NoError(t, err)
+	assert.Equal(t, "select id\nfrom users\nwhere (`name` = 'SELECT')\norder by id", fake.statements()[0].query)
+
+	for _, sess := range testSession {
+		sess := sess.NewSession(nil)
+		key := fmt.Sprintf("format_%d_", nextID())
+		sess.Format = Format{Case: KeywordLower, Newline: true}
+		_, err := sess.InsertInto("dbr_keys").Columns("key_value", "val_value").
+			Values(key+"1", "SELECT FROM").Values(key+"2", nil).Exec()
+		assert.NoError(t, err)
+
+		var values []string
+		_, err = sess.Select("val_value").From("dbr_keys").
+			Where(And(Like("key_value", key+"%"), Expr("val_value IS NOT NULL"))).OrderBy("key_value").Load(&values)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"SELECT FROM"}, values)
+
+		sess.Format = Format{Case: KeywordUpper}
+		count, err := sess.Select("COUNT(*)").From("dbr_keys").Where(Like("key_value", key+"%")).ReturnInt64()
+		assert.NoError(t, err)
+		assert.EqualValues(t, 2, count)
+	}
+}
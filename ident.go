@@ -14,12 +14,29 @@ func (i I) As(alias string) Builder {
 	return as(i, alias)
 }
 
-func as(expr interface{}, alias string) Builder {
-	return BuildFunc(func(d Dialect, buf Buffer) error {
-		buf.WriteString(placeholder)
-		buf.WriteValue(expr)
-		buf.WriteString(" AS ")
-		buf.WriteString(d.QuoteIdent(alias))
-		return nil
-	})
+// As creates an alias for expr quoted in the dialect, e.g. As("users.id", "user_id") is
+// `users`.`id` AS `user_id` in MySQL. A string expr is an identifier, use Expr for expressions.
+// It can be used for columns of Select and tables of From and Join.
+func As(expr interface{}, alias string) Builder {
+	if s, ok := expr.(string); ok {
+		return as(I(s), alias)
+	}
+	return as(expr, alias)
+}
+
+type alias struct {
+	expr interface{}
+	name string
+}
+
+func as(expr interface{}, name string) Builder {
+	return &alias{expr: expr, name: name}
+}
+
+func (a *alias) Build(d Dialect, buf Buffer) error {
+	buf.WriteString(placeholder)
+	buf.WriteValue(a.expr)
+	buf.WriteString(" AS ")
+	buf.WriteString(d.QuoteIdent(a.name))
+	return nil
 }
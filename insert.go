@@ -1,7 +1,6 @@
 package dbr
 
 import (
-	"bytes"
 	"fmt"
 	"reflect"
 	"sort"
@@ -31,15 +30,30 @@ type InsertStmt interface {
 	Record(structValue interface{}) InsertStmt
 	OnConflictMap(constraint string, actions map[string]interface{}) InsertStmt
 	OnConflict(constraint string) ConflictStmt
+	Returning(column ...string) InsertStmt
+	Ignore(column ...string) InsertStmt
+	OmitZero() InsertStmt
+	Cast(column, typ string) InsertStmt
+	Shard(key interface{}) InsertStmt
 }
 
 type insertStmt struct {
 	raw
 
-	Table    string
-	Column   []string
-	Value    [][]interface{}
-	Conflict *conflictStmt
+	Table        string
+	Column       []string
+	Value        [][]interface{}
+	Conflict     *conflictStmt
+	ReturnColumn []string
+	IgnoreDup    bool
+	IgnoreColumn []string
+	IsOmitZero   bool
+	RecordColumn bool
+	CastType     map[string]string
+	ShardKey     interface{}
+	IsSharded    bool
+
+	err error
 }
 
 // Proposed is reference to proposed value in on conflict clause
@@ -64,31 +78,75 @@ func (b *insertStmt) Build(d Dialect, buf Buffer) error {
 		return ErrColumnNotSpecified
 	}
 
-	buf.WriteString("INSERT INTO ")
-	buf.WriteString(d.QuoteIdent(b.Table))
+	if b.err != nil {
+		return b.err
+	}
+
+	insert, ignore := "INSERT INTO", ""
+	if b.IgnoreDup {
+		if b.Conflict != nil && len(b.Conflict.actions) > 0 {
+			return ErrNotSupported
+		}
+		i, ok := baseDialect(d).(InsertIgnoreDialect)
+		if !ok {
+			return ErrNotSupported
+		}
+		insert, ignore = i.InsertIgnore(b.IgnoreColumn)
+		if len(insert) == 0 {
+			return ErrNotSupported
+		}
+	}
+
+	table := b.Table
+	if b.IsSharded {
+		var err error
+		table, err = resolveTable(d, b.Table, b.ShardKey)
+		if err != nil {
+			return err
+		}
+	}
+
+	buf.WriteString(insert)
+	buf.WriteString(" ")
+	buf.WriteString(d.QuoteIdent(table))
 
-	placeholderBuf := new(bytes.Buffer)
-	placeholderBuf.WriteString("(")
 	buf.WriteString(" (")
 	for i, col := range b.Column {
 		if i > 0 {
 			buf.WriteString(",")
-			placeholderBuf.WriteString(",")
 		}
 		buf.WriteString(d.QuoteIdent(col))
-		placeholderBuf.WriteString(placeholder)
 	}
 	buf.WriteString(") VALUES ")
-	placeholderBuf.WriteString(")")
-	placeholderStr := placeholderBuf.String()
+
+	var cast []string
+	if c, ok := baseDialect(d).(CastDialect); ok && len(b.CastType) > 0 {
+		cast = make([]string, len(b.Column))
+		for i, col := range b.Column {
+			if typ, ok := b.CastType[col]; ok {
+				cast[i] = c.Cast(typ)
+			}
+		}
+	}
 
 	for i, tuple := range b.Value {
 		if i > 0 {
 			buf.WriteString(", ")
 		}
-		buf.WriteString(placeholderStr)
-
-		buf.WriteValue(tuple...)
+		buf.WriteString("(")
+		for j, v := range tuple {
+			if j > 0 {
+				buf.WriteString(",")
+			}
+			err := buildValue(d, buf, v)
+			if err != nil {
+				return err
+			}
+			if _, ok := v.(Builder); !ok && j < len(cast) {
+				buf.WriteString(cast[j])
+			}
+		}
+		buf.WriteString(")")
 	}
 	if b.Conflict != nil && len(b.Conflict.actions) > 0 {
 		keyword := d.OnConflict(b.Conflict.constraint)
@@ -113,6 +171,28 @@ func (b *insertStmt) Build(d Dialect, buf Buffer) error {
 		}
 	}
 
+	if len(ignore) > 0 {
+		buf.WriteString(" ")
+		buf.WriteString(ignore)
+	}
+
+	if len(b.ReturnColumn) > 0 {
+		if !supportsReturning(d) {
+			return ErrNotSupported
+		}
+		buf.WriteString(" RETURNING ")
+		for i, col := range b.ReturnColumn {
+			if i > 0 {
+				buf.WriteString(",")
+			}
+			if col == "*" {
+				buf.WriteString(col)
+			} else {
+				buf.WriteString(d.QuoteIdent(col))
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -147,7 +227,8 @@ func (b *insertStmt) Columns(column ...string) InsertStmt {
 	return b
 }
 
-// Values adds a tuple for columns
+// Values adds a tuple for columns.
+// A Builder value (e.g. Expr("ST_GeomFromText(?)", wkt)) is rendered in place of the placeholder.
 func (b *insertStmt) Values(value ...interface{}) InsertStmt {
 	b.Value = append(b.Value, value)
 	return b
@@ -155,28 +236,29 @@ func (b *insertStmt) Values(value ...interface{}) InsertStmt {
 
 // Record adds a tuple for columns from a struct if no columns where
 // specified yet for this insert, the record fields will be used to populate the columns.
+// Columns populated from the record skip zero fields tagged with pk (e.g. `db:"id,pk"`),
+// so the database generates the primary key, and all zero fields after OmitZero.
+// The columns are the same for all records, Build returns ErrRecordColumns
+// if they skip different fields, e.g. a zero and a non-zero key.
 func (b *insertStmt) Record(structValue interface{}) InsertStmt {
 	v := reflect.Indirect(reflect.ValueOf(structValue))
 
 	if v.Kind() == reflect.Struct {
 		var value []interface{}
-		m := structMap(v.Type())
+		m, crypt := structFields(v.Type())
 
 		// populate columns from available record fields
 		// if no columns were specified up to this point
 		if len(b.Column) == 0 {
-			b.Column = make([]string, 0, len(m))
-			for key := range m {
-				b.Column = append(b.Column, key)
-			}
-
-			// ensure that the column ordering is deterministic
-			sort.Strings(b.Column)
+			b.Column = b.recordColumns(v, m)
+			b.RecordColumn = true
+		} else if b.RecordColumn && b.err == nil && !equalColumns(b.Column, b.recordColumns(v, m)) {
+			b.err = ErrRecordColumns
 		}
 
 		for _, key := range b.Column {
 			if index, ok := m[key]; ok {
-				value = append(value, v.FieldByIndex(index).Interface())
+				value = append(value, recordValue(key, v.FieldByIndex(index), crypt))
 			} else {
 				value = append(value, nil)
 			}
@@ -187,6 +269,62 @@ func (b *insertStmt) Record(structValue interface{}) InsertStmt {
 	return b
 }
 
+// recordColumns returns the sorted columns of the fields of record v,
+// without zero pk fields and zero fields after OmitZero
+func (b *insertStmt) recordColumns(v reflect.Value, m map[string][]int) []string {
+	column := make([]string, 0, len(m))
+	for key, index := range m {
+		if isEmbeddedStruct(v.Type(), index) {
+			continue
+		}
+		if (b.IsOmitZero || isPrimaryKey(v.Type(), index)) && isZero(v.FieldByIndex(index)) {
+			continue
+		}
+		column = append(column, key)
+	}
+
+	// ensure that the column ordering is deterministic
+	sort.Strings(column)
+	return column
+}
+
+func equalColumns(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// OmitZero makes Record skip zero fields of the records when it populates the columns,
+// so the database defaults are used for them. It must be called before Record.
+func (b *insertStmt) OmitZero() InsertStmt {
+	b.IsOmitZero = true
+	return b
+}
+
+// Cast casts the values of column to typ, e.g. Cast("payload", "jsonb") renders "?::jsonb" in PostgreSQL
+// for drivers which can not infer the type of the value. It is ignored in other dialects
+// and for values which are builders, e.g. Expr. typ is written as is, so it must not contain user input.
+func (b *insertStmt) Cast(column, typ string) InsertStmt {
+	if b.CastType == nil {
+		b.CastType = make(map[string]string)
+	}
+	b.CastType[column] = typ
+	return b
+}
+
+// Shard makes the table the physical table for the shard key, resolved with Session.TableResolver
+func (b *insertStmt) Shard(key interface{}) InsertStmt {
+	b.ShardKey = key
+	b.IsSharded = true
+	return b
+}
+
 // OnConflictMap allows to add actions for constraint violation, e.g UPSERT
 func (b *insertStmt) OnConflictMap(constraint string, actions map[string]interface{}) InsertStmt {
 	b.Conflict = &conflictStmt{constraint: constraint, actions: actions}
@@ -198,3 +336,21 @@ func (b *insertStmt) OnConflict(constraint string) ConflictStmt {
 	b.Conflict = &conflictStmt{constraint: constraint, actions: make(map[string]interface{})}
 	return b.Conflict
 }
+
+// Returning adds `RETURNING column, ...` to return values of inserted rows, e.g. generated ids,
+// "*" returns all columns. Build returns ErrNotSupported if the dialect does not support RETURNING.
+func (b *insertStmt) Returning(column ...string) InsertStmt {
+	b.ReturnColumn = append(b.ReturnColumn, column...)
+	return b
+}
+
+// Ignore skips rows violating a unique constraint instead of failing, e.g. `INSERT IGNORE`
+// on MySQL, `INSERT OR IGNORE` on SQLite and `ON CONFLICT DO NOTHING` on PostgreSQL.
+// In PostgreSQL column is the conflict target, `ON CONFLICT (column, ...) DO NOTHING`,
+// so violations of other constraints still fail; MySQL and SQLite ignore any constraint.
+// Build returns ErrNotSupported if the dialect can not ignore conflicts or OnConflict actions are set.
+func (b *insertStmt) Ignore(column ...string) InsertStmt {
+	b.IgnoreDup = true
+	b.IgnoreColumn = column
+	return b
+}
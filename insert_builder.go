@@ -16,6 +16,12 @@ type InsertBuilder interface {
 	OnConflictMap(constraint string, actions map[string]interface{}) InsertBuilder
 	OnConflict(constraint string) ConflictStmt
 	Pair(column string, value interface{}) InsertBuilder
+	Returning(column ...string) InsertBuilder
+	Ignore(column ...string) InsertBuilder
+	OmitZero() InsertBuilder
+	Cast(column, typ string) InsertBuilder
+	Shard(key interface{}) InsertBuilder
+	Load() (int, error)
 }
 
 // InsertBuilder builds "INSERT ..." stmt
@@ -25,6 +31,7 @@ type insertBuilder struct {
 
 	Dialect    Dialect
 	RecordID   reflect.Value
+	Records    []reflect.Value
 	insertStmt *insertStmt
 }
 
@@ -128,10 +135,112 @@ func (b *insertBuilder) Record(structValue interface{}) InsertBuilder {
 		}
 	}
 
+	var record reflect.Value
+	if v.Kind() == reflect.Struct && v.CanSet() {
+		record = v
+	}
+	b.Records = append(b.Records, record)
+
 	b.insertStmt.Record(structValue)
 	return b
 }
 
+// Returning adds `RETURNING column, ...`, see Load
+func (b *insertBuilder) Returning(column ...string) InsertBuilder {
+	b.insertStmt.Returning(column...)
+	return b
+}
+
+// Load executes the stmt with RETURNING and writes the returned columns back
+// to the fields of the records, e.g. generated ids. All rows must be added by Record with pointers
+// to structs, otherwise ErrInvalidPointer is returned.
+// Returned rows are matched to records by position, which assumes the database returns rows
+// in insertion order, as PostgreSQL and SQLite do for INSERT ... VALUES.
+// Records are not changed if the number of returned rows differs (e.g. ON CONFLICT DO NOTHING),
+// ErrReturningCount is returned instead. It returns the number of returned rows.
+func (b *insertBuilder) Load() (int, error) {
+	if len(b.insertStmt.ReturnColumn) == 0 {
+		return 0, ErrColumnNotSpecified
+	}
+	if len(b.Records) != len(b.insertStmt.Value) {
+		return 0, ErrInvalidPointer
+	}
+	for _, record := range b.Records {
+		if !record.IsValid() {
+			return 0, ErrInvalidPointer
+		}
+	}
+
+	var returned []reflect.Value
+	err := queryRows(b.runner, b.EventReceiver, b, b.Dialect, func(rows *sql.Rows) error {
+		defer rows.Close()
+		column, err := rows.Columns()
+		if err != nil {
+			return err
+		}
+		for rows.Next() {
+			if len(returned) == len(b.Records) {
+				return ErrReturningCount
+			}
+			elem := reflect.New(b.Records[len(returned)].Type()).Elem()
+			ptr := getStructFieldsExtractor(elem.Type())(column, elem)
+			err = cryptDest(column, ptr, cryptColumns(elem.Type()), b.runner.getSession().Crypter)
+			if err != nil {
+				return err
+			}
+			err = rows.Scan(ptr...)
+			if err != nil {
+				return err
+			}
+			returned = append(returned, elem)
+		}
+		err = rows.Err()
+		if err != nil {
+			return err
+		}
+		if len(returned) != len(b.Records) {
+			return ErrReturningCount
+		}
+		for i, record := range b.Records {
+			m := structMap(record.Type())
+			for _, col := range column {
+				if index, ok := m[col]; ok {
+					record.FieldByIndex(index).Set(returned[i].FieldByIndex(index))
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return len(returned), nil
+}
+
+// Ignore skips rows violating a unique constraint, see InsertStmt.Ignore
+func (b *insertBuilder) Ignore(column ...string) InsertBuilder {
+	b.insertStmt.Ignore(column...)
+	return b
+}
+
+// OmitZero makes Record skip zero fields when it populates the columns, see InsertStmt.OmitZero
+func (b *insertBuilder) OmitZero() InsertBuilder {
+	b.insertStmt.OmitZero()
+	return b
+}
+
+// Cast casts the values of column to typ in PostgreSQL, see InsertStmt.Cast
+func (b *insertBuilder) Cast(column, typ string) InsertBuilder {
+	b.insertStmt.Cast(column, typ)
+	return b
+}
+
+// Shard makes the table the physical table for the shard key, see Session.TableResolver
+func (b *insertBuilder) Shard(key interface{}) InsertBuilder {
+	b.insertStmt.Shard(key)
+	return b
+}
+
 // OnConflictMap allows to add actions for constraint violation, e.g UPSERT
 func (b *insertBuilder) OnConflictMap(constraint string, actions map[string]interface{}) InsertBuilder {
 	b.insertStmt.OnConflictMap(constraint, actions)
@@ -0,0 +1,124 @@
+package dbr
+
+import "sync"
+
+// StreamInserter inserts rows sent by producers in batches in the background,
+// see Session.StreamInsert. Send and Close are safe for concurrent use.
+type StreamInserter struct {
+	insert    func() InsertBuilder
+	column    []string
+	batchSize int
+
+	row  chan []interface{}
+	done chan struct{}
+
+	mu     sync.RWMutex
+	closed bool
+
+	errMu sync.Mutex
+	err   error
+}
+
+// StreamInsert starts a StreamInserter which inserts rows of column into table
+// with one INSERT per batchSize rows. A partial batch is inserted by Close.
+// After an insert fails the following rows are discarded, the error is returned by Send, Err and Close.
+func (sess *Session) StreamInsert(table string, column []string, batchSize int) *StreamInserter {
+	return newStreamInserter(func() InsertBuilder {
+		return sess.InsertInto(table)
+	}, column, batchSize)
+}
+
+// StreamInsert starts a StreamInserter in the transaction, see Session.StreamInsert.
+// The transaction must not be committed before Close returns.
+func (tx *Tx) StreamInsert(table string, column []string, batchSize int) *StreamInserter {
+	return newStreamInserter(func() InsertBuilder {
+		return tx.InsertInto(table)
+	}, column, batchSize)
+}
+
+func newStreamInserter(insert func() InsertBuilder, column []string, batchSize int) *StreamInserter {
+	if batchSize < 1 {
+		batchSize = 1
+	}
+	s := &StreamInserter{
+		insert:    insert,
+		column:    column,
+		batchSize: batchSize,
+		row:       make(chan []interface{}, batchSize),
+		done:      make(chan struct{}),
+	}
+	go s.run()
+	return s
+}
+
+func (s *StreamInserter) run() {
+	defer close(s.done)
+	batch := make([][]interface{}, 0, s.batchSize)
+	for row := range s.row {
+		if s.Err() != nil {
+			// drain rows so that Send does not block
+			continue
+		}
+		batch = append(batch, row)
+		if len(batch) == s.batchSize {
+			s.flush(batch)
+			batch = batch[:0]
+		}
+	}
+	if len(batch) > 0 && s.Err() == nil {
+		s.flush(batch)
+	}
+}
+
+func (s *StreamInserter) flush(batch [][]interface{}) {
+	b := s.insert().Columns(s.column...)
+	for _, row := range batch {
+		b.Values(row...)
+	}
+	_, err := b.Exec()
+	if err != nil {
+		s.errMu.Lock()
+		s.err = err
+		s.errMu.Unlock()
+	}
+}
+
+// Send queues a row of values in column order, it blocks while the queue is full.
+// It returns ErrTupleLength if the number of values does not match the columns,
+// ErrStreamClosed after Close, and the error of a failed insert.
+func (s *StreamInserter) Send(value ...interface{}) error {
+	if len(value) != len(s.column) {
+		return ErrTupleLength
+	}
+	err := s.Err()
+	if err != nil {
+		return err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.closed {
+		return ErrStreamClosed
+	}
+	s.row <- value
+	return nil
+}
+
+// Err returns the error of a failed insert
+func (s *StreamInserter) Err() error {
+	s.errMu.Lock()
+	defer s.errMu.Unlock()
+	return s.err
+}
+
+// Close inserts the remaining rows and waits for the inserts to finish,
+// it returns the error of a failed insert
+func (s *StreamInserter) Close() error {
+	s.mu.Lock()
+	if !s.closed {
+		s.closed = true
+		close(s.row)
+	}
+	s.mu.Unlock()
+	<-s.done
+	return s.Err()
+}
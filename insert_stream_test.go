@@ -0,0 +1,76 @@
+package dbr
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/lianchengwu/dbr/dialect"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStreamInsert(t *testing.T) {
+	for _, sess := range testSession {
+		prefix := fmt.Sprintf("stream_%d_", nextID())
+		ins := sess.StreamInsert("dbr_keys", []string{"key_value", "val_value"}, 3)
+
+		// concurrent producers
+		var wg sync.WaitGroup
+		for p := 0; p < 2; p++ {
+			wg.Add(1)
+			go func(p int) {
+				defer wg.Done()
+				for i := 0; i < 4; i++ {
+					assert.NoError(t, ins.Send(fmt.Sprintf("%s%d_%d", prefix, p, i), "v"))
+				}
+			}(p)
+		}
+		wg.Wait()
+		assert.NoError(t, ins.Close())
+		assert.Equal(t, ErrStreamClosed, ins.Send("a", "b"))
+
+		var keys []string
+		_, err := sess.Select("key_value").From("dbr_keys").Where(Eq("val_value", "v")).Load(&keys)
+		assert.NoError(t, err)
+		count := 0
+		for _, key := range keys {
+			if len(key) > len(prefix) && key[:len(prefix)] == prefix {
+				count++
+			}
+		}
+		assert.Equal(t, 8, count)
+	}
+}
+
+func TestStreamInsertBatch(t *testing.T) {
+	sess, fake := newFakeSession(dialect.MySQL)
+	ins := sess.StreamInsert("t", []string{"a", "b"}, 2)
+	for i := 0; i < 5; i++ {
+		assert.NoError(t, ins.Send(i, "x"))
+	}
+	assert.Equal(t, ErrTupleLength, ins.Send(1))
+	assert.NoError(t, ins.Close())
+	assert.NoError(t, ins.Close())
+
+	stmts := fake.statements()
+	if assert.Len(t, stmts, 3) {
+		assert.Equal(t, "INSERT INTO `t` (`a`,`b`) VALUES (0,'x'), (1,'x')", stmts[0].query)
+		assert.Equal(t, "INSERT INTO `t` (`a`,`b`) VALUES (2,'x'), (3,'x')", stmts[1].query)
+		// partial batch is inserted by Close
+		assert.Equal(t, "INSERT INTO `t` (`a`,`b`) VALUES (4,'x')", stmts[2].query)
+	}
+}
+
+func TestStreamInsertError(t *testing.T) {
+	sess, dbmock := newSessionMock()
+	dbmock.ExpectExec("INSERT INTO `t`").WillReturnError(errors.New("disk full"))
+
+	ins := sess.StreamInsert("t", []string{"a"}, 2)
+	assert.NoError(t, ins.Send(1))
+	assert.NoError(t, ins.Send(2))
+	assert.EqualError(t, ins.Close(), "disk full")
+	assert.EqualError(t, ins.Err(), "disk full")
+	assert.EqualError(t, ins.Send(3), "disk full")
+	assert.NoError(t, dbmock.ExpectationsWereMet())
+}
@@ -1,8 +1,10 @@
 package dbr
 
 import (
+	"regexp"
 	"testing"
 
+	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/lianchengwu/dbr/dialect"
 	"github.com/stretchr/testify/assert"
 )
@@ -37,6 +39,108 @@ func TestInsertRecordNoColumns(t *testing.T) {
 	assert.Equal(t, []interface{}{2, "two", 1, "one"}, buf.Value())
 }
 
+type insertTenant struct {
+	TenantID int64
+}
+
+func TestInsertRecordZero(t *testing.T) {
+	type account struct {
+		insertTenant
+		ID       int64 `db:"id,pk"`
+		Name     string
+		Balance  int
+		Nickname *string `db:"nick_name"`
+	}
+	for _, test := range []struct {
+		omitZero bool
+		record   account
+		query    string
+		value    []interface{}
+	}{
+		{
+			// a zero id is generated by the database
+			record: account{Name: "a"},
+			query:  "INSERT INTO `accounts` (`balance`,`name`,`nick_name`,`tenant_id`) VALUES (?,?,?,?)",
+			value:  []interface{}{0, "a", (*string)(nil), int64(0)},
+		},
+		{
+			record: account{ID: 7, Name: "a"},
+			query:  "INSERT INTO `accounts` (`balance`,`id`,`name`,`nick_name`,`tenant_id`) VALUES (?,?,?,?,?)",
+			value:  []interface{}{0, int64(7), "a", (*string)(nil), int64(0)},
+		},
+		{
+			omitZero: true,
+			record:   account{Name: "a"},
+			query:    "INSERT INTO `accounts` (`name`) VALUES (?)",
+			value:    []interface{}{"a"},
+		},
+		{
+			// fields of unexported embedded structs are columns
+			omitZero: true,
+			record:   account{insertTenant: insertTenant{TenantID: 3}, Name: "a"},
+			query:    "INSERT INTO `accounts` (`name`,`tenant_id`) VALUES (?,?)",
+			value:    []interface{}{"a", int64(3)},
+		},
+	} {
+		stmt := InsertInto("accounts")
+		if test.omitZero {
+			stmt.OmitZero()
+		}
+		buf := NewBuffer()
+		err := stmt.Record(&test.record).Build(dialect.MySQL, buf)
+		assert.NoError(t, err)
+		assert.Equal(t, test.query, buf.String())
+		assert.Equal(t, test.value, buf.Value())
+	}
+
+	// zero fields without pk are inserted
+	buf := NewBuffer()
+	err := InsertInto("people").Record(&returningRecord{Name: "a"}).Build(dialect.MySQL, buf)
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO `people` (`id`,`name`) VALUES (?,?)", buf.String())
+
+	// columns of records without zero fields
+	buf = NewBuffer()
+	err = InsertInto("accounts").OmitZero().
+		Record(&account{Name: "a", Balance: 1}).
+		Record(&account{Name: "b", Balance: 2}).
+		Build(dialect.MySQL, buf)
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO `accounts` (`balance`,`name`) VALUES (?,?), (?,?)", buf.String())
+
+	// a later record skipping other fields would lose them
+	for _, stmt := range []InsertStmt{
+		InsertInto("accounts").Record(&account{Name: "a"}).Record(&account{ID: 7, Name: "b"}),
+		InsertInto("accounts").OmitZero().Record(&account{Name: "a"}).Record(&account{Name: "b", Balance: 2}),
+	} {
+		err = stmt.Build(dialect.MySQL, NewBuffer())
+		assert.Equal(t, ErrRecordColumns, err)
+	}
+}
+
+func TestInsertStmtCast(t *testing.T) {
+	builder := InsertInto("events").Columns("id", "payload", "tags").Cast("payload", "jsonb").Cast("tags", "text[]").
+		Values(1, `{"a":1}`, "{x}").
+		Values(2, Expr("?::json", `{}`), "{y,z}")
+
+	buf := NewBuffer()
+	err := builder.Build(dialect.PostgreSQL, buf)
+	assert.NoError(t, err)
+	assert.Equal(t, `INSERT INTO "events" ("id","payload","tags") VALUES (?,?::jsonb,?::text[]), (?,?::json,?::text[])`, buf.String())
+	assert.Equal(t, []interface{}{1, `{"a":1}`, "{x}", 2, `{}`, "{y,z}"}, buf.Value())
+
+	buf = NewBuffer()
+	err = builder.Build(dialect.MySQL, buf)
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO `events` (`id`,`payload`,`tags`) VALUES (?,?,?), (?,?::json,?)", buf.String())
+
+	// casts follow the columns of records
+	buf = NewBuffer()
+	err = InsertInto("table").Cast("b", "jsonb").Record(&insertTest{A: 2, C: "{}"}).Build(dialect.PostgreSQL, buf)
+	assert.NoError(t, err)
+	assert.Equal(t, `INSERT INTO "table" ("a","b") VALUES (?,?::jsonb)`, buf.String())
+}
+
 func TestInsertOnConflictStmt(t *testing.T) {
 	buf := NewBuffer()
 	exp := Expr("a + ?", 1)
@@ -58,6 +162,21 @@ func TestInsertOnConflictMapStmt(t *testing.T) {
 	assert.Equal(t, []interface{}{1, "one", exp, "one"}, buf.Value())
 }
 
+func TestInsertStmtExprValues(t *testing.T) {
+	buf := NewBuffer()
+	builder := InsertInto("table").Columns("a", "geom", "c").
+		Values(1, Expr("ST_GeomFromText(?, ?)", "POINT(1 1)", 4326), "one").
+		Values(2, Expr("NULL"), "two")
+	err := builder.Build(dialect.PostgreSQL, buf)
+	assert.NoError(t, err)
+	assert.Equal(t, `INSERT INTO "table" ("a","geom","c") VALUES (?,ST_GeomFromText(?, ?),?), (?,NULL,?)`, buf.String())
+	assert.Equal(t, []interface{}{1, "POINT(1 1)", 4326, "one", 2, "two"}, buf.Value())
+
+	query, err := InterpolateForDialect(buf.String(), buf.Value(), dialect.PostgreSQL)
+	assert.NoError(t, err)
+	assert.Equal(t, `INSERT INTO "table" ("a","geom","c") VALUES (1,ST_GeomFromText('POINT(1 1)', 4326),'one'), (2,NULL,'two')`, query)
+}
+
 func BenchmarkInsertValuesSQL(b *testing.B) {
 	buf := NewBuffer()
 	for i := 0; i < b.N; i++ {
@@ -74,3 +193,93 @@ func BenchmarkInsertRecordSQL(b *testing.B) {
 		}).Build(dialect.MySQL, buf)
 	}
 }
+
+type returningRecord struct {
+	ID   int64
+	Name string
+}
+
+func TestInsertReturningLoad(t *testing.T) {
+	for _, sess := range testSession {
+		if sess.Dialect != dialect.PostgreSQL {
+			// go-sqlite3 v1.11.0 bundles SQLite 3.29 without RETURNING
+			continue
+		}
+		for _, v := range []string{
+			"DROP TABLE IF EXISTS dbr_returning",
+			"CREATE TABLE dbr_returning (id SERIAL PRIMARY KEY, name varchar(255) NOT NULL)",
+		} {
+			_, err := sess.Exec(v)
+			assert.NoError(t, err)
+		}
+
+		records := []*returningRecord{{Name: "one"}, {Name: "two"}, {Name: "three"}}
+		b := sess.InsertInto("dbr_returning").Columns("name")
+		for _, r := range records {
+			b.Record(r)
+		}
+		count, err := b.Returning("id", "name").Load()
+		assert.NoError(t, err)
+		assert.Equal(t, 3, count)
+
+		for _, r := range records {
+			var name string
+			err = sess.Select("name").From("dbr_returning").Where(Eq("id", r.ID)).LoadValue(&name)
+			assert.NoError(t, err)
+			assert.Equal(t, r.Name, name)
+		}
+		assert.NotEqual(t, records[0].ID, records[1].ID)
+	}
+}
+
+func TestInsertReturningOrder(t *testing.T) {
+	db, dbmock, err := sqlmock.New()
+	assert.NoError(t, err)
+	conn := Connection{DB: db, Dialect: dialect.PostgreSQL, EventReceiver: nullReceiver}
+	sess := conn.NewSession(nil)
+
+	query := regexp.QuoteMeta(`INSERT INTO "people" ("name") VALUES ('one'), ('two') RETURNING "id"`)
+	dbmock.ExpectQuery(query).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7).AddRow(8))
+	dbmock.ExpectQuery(query).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(9))
+
+	one, two := &returningRecord{Name: "one"}, &returningRecord{Name: "two"}
+	count, err := sess.InsertInto("people").Columns("name").Record(one).Record(two).Returning("id").Load()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.Equal(t, &returningRecord{ID: 7, Name: "one"}, one)
+	assert.Equal(t, &returningRecord{ID: 8, Name: "two"}, two)
+
+	// records are unchanged if a row is missing
+	one, two = &returningRecord{Name: "one"}, &returningRecord{Name: "two"}
+	_, err = sess.InsertInto("people").Columns("name").Record(one).Record(two).Returning("id").Load()
+	assert.Equal(t, ErrReturningCount, err)
+	assert.Zero(t, one.ID)
+	assert.NoError(t, dbmock.ExpectationsWereMet())
+}
+
+func TestInsertReturningStmt(t *testing.T) {
+	buf := NewBuffer()
+	err := InsertInto("table").Columns("a").Values(1).Returning("id", "created_at").Build(dialect.PostgreSQL, buf)
+	assert.NoError(t, err)
+	assert.Equal(t, `INSERT INTO "table" ("a") VALUES (?) RETURNING "id","created_at"`, buf.String())
+
+	err = InsertInto("table").Columns("a").Values(1).Returning("id").Build(dialect.MySQL, NewBuffer())
+	assert.Equal(t, ErrNotSupported, err)
+
+	// RETURNING requires SQLite 3.35
+	err = InsertInto("table").Columns("a").Values(1).Returning("id").Build(dialect.SQLite3, NewBuffer())
+	assert.Equal(t, ErrNotSupported, err)
+	buf = NewBuffer()
+	err = InsertInto("table").Columns("a").Values(1).Returning("id").Build(dialect.SQLite3Version(3, 35), buf)
+	assert.NoError(t, err)
+	assert.Equal(t, `INSERT INTO "table" ("a") VALUES (?) RETURNING "id"`, buf.String())
+
+	sess, fake := newFakeSession(dialect.PostgreSQL)
+	_, err = sess.InsertInto("table").Columns("name").Values("a").Returning("id").Load()
+	assert.Equal(t, ErrInvalidPointer, err)
+	_, err = sess.InsertInto("table").Columns("name").Record(returningRecord{Name: "a"}).Returning("id").Load()
+	assert.Equal(t, ErrInvalidPointer, err)
+	_, err = sess.InsertInto("table").Columns("name").Record(&returningRecord{Name: "a"}).Load()
+	assert.Equal(t, ErrColumnNotSpecified, err)
+	assert.Empty(t, fake.statements())
+}
@@ -14,7 +14,17 @@ type interpolator struct {
 	Buffer
 	Dialect
 	IgnoreBinary bool
-	N            int
+	// Bind writes placeholders for all values, see Session.DisableInterpolation
+	Bind bool
+	// Strict rejects strings with NUL bytes, see Session.StrictInterpolation
+	Strict bool
+	// MaxValueSize limits the size of strings and []byte, see Session.MaxValueSize
+	MaxValueSize int
+	// DurationUnit is the unit of time.Duration, see Session.DurationUnit
+	DurationUnit time.Duration
+	// Crypter encrypts the values of crypt fields, see Session.Crypter
+	Crypter Crypter
+	N       int
 }
 
 // InterpolateForDialect replaces placeholder in query with corresponding value in dialect
@@ -44,12 +54,23 @@ func (i *interpolator) interpolate(query string, value []interface{}) error {
 		}
 
 		i.WriteString(query[:index])
-		if _, ok := value[valueIndex].([]byte); ok && i.IgnoreBinary {
+		v := value[valueIndex]
+		if cv, ok := v.(cryptValue); ok {
+			var err error
+			v, err = i.encrypt(cv)
+			if err != nil {
+				return err
+			}
+		}
+		if b, ok := v.([]byte); ok && i.IgnoreBinary {
+			if i.MaxValueSize > 0 && len(b) > i.MaxValueSize {
+				return ErrValueTooLarge
+			}
 			i.WriteString(i.Placeholder(i.N))
 			i.N++
-			i.WriteValue(value[valueIndex])
+			i.WriteValue(v)
 		} else {
-			err := i.encodePlaceholder(value[valueIndex])
+			err := i.encodePlaceholder(v)
 			if err != nil {
 				return err
 			}
@@ -64,6 +85,27 @@ func (i *interpolator) interpolate(query string, value []interface{}) error {
 	return nil
 }
 
+// checkValue rejects strings with NUL bytes if Strict is set,
+// and strings and []byte larger than MaxValueSize
+func (i *interpolator) checkValue(v reflect.Value) error {
+	switch v.Kind() {
+	case reflect.String:
+		if i.Strict && strings.IndexByte(v.String(), 0) >= 0 {
+			return ErrNulByte
+		}
+	case reflect.Slice:
+		if v.Type().Elem().Kind() != reflect.Uint8 {
+			return nil
+		}
+	default:
+		return nil
+	}
+	if i.MaxValueSize > 0 && v.Len() > i.MaxValueSize {
+		return ErrValueTooLarge
+	}
+	return nil
+}
+
 func (i *interpolator) encodePlaceholder(value interface{}) error {
 	if builder, ok := value.(Builder); ok {
 		pbuf := NewBuffer()
@@ -91,6 +133,18 @@ func (i *interpolator) encodePlaceholder(value interface{}) error {
 		return nil
 	}
 
+	if i.Bind && !isList(value) {
+		// driver.Valuer values are passed to the driver as is
+		err := i.checkValue(reflect.ValueOf(value))
+		if err != nil {
+			return err
+		}
+		i.WriteString(i.Placeholder(i.N))
+		i.N++
+		i.WriteValue(value)
+		return nil
+	}
+
 	if valuer, ok := value.(driver.Valuer); ok {
 		// get driver.Valuer's data
 		var err error
@@ -104,9 +158,26 @@ func (i *interpolator) encodePlaceholder(value interface{}) error {
 		i.WriteString("NULL")
 		return nil
 	}
+	if d, ok := value.(time.Duration); ok {
+		if i.DurationUnit > 0 {
+			i.WriteString(strconv.FormatInt(int64(d/i.DurationUnit), 10))
+			return nil
+		}
+		if e, ok := baseDialect(i.Dialect).(DurationDialect); ok {
+			if s := e.EncodeDuration(d); s != "" {
+				i.WriteString(s)
+				return nil
+			}
+		}
+	}
+	// named types are encoded by their kind, e.g. type Celsius float64
 	v := reflect.ValueOf(value)
 	switch v.Kind() {
 	case reflect.String:
+		err := i.checkValue(v)
+		if err != nil {
+			return err
+		}
 		i.WriteString(i.EncodeString(v.String()))
 		return nil
 	case reflect.Bool:
@@ -129,6 +200,10 @@ func (i *interpolator) encodePlaceholder(value interface{}) error {
 	case reflect.Slice:
 		if v.Type().Elem().Kind() == reflect.Uint8 {
 			// []byte
+			err := i.checkValue(v)
+			if err != nil {
+				return err
+			}
 			i.WriteString(i.EncodeBytes(v.Bytes()))
 			return nil
 		}
@@ -180,6 +255,34 @@ func (i *interpolator) encodePlaceholder(value interface{}) error {
 	return ErrNotSupported
 }
 
+// isList reports whether value is a slice or map to expand for IN, see listValue
+func isList(value interface{}) bool {
+	_, ok := listValue(value)
+	return ok
+}
+
+// listValue returns the slice or map of value to expand for IN following pointers, e.g. *[]int.
+// []byte and driver.Valuer (e.g. sql.NullInt64 or array types of drivers) are not lists.
+func listValue(value interface{}) (reflect.Value, bool) {
+	v := reflect.ValueOf(value)
+	for v.IsValid() && !v.Type().Implements(typeValuer) {
+		switch v.Kind() {
+		case reflect.Ptr:
+			if v.IsNil() {
+				return v, false
+			}
+			v = v.Elem()
+		case reflect.Slice:
+			return v, v.Type().Elem().Kind() != reflect.Uint8
+		case reflect.Map:
+			return v, true
+		default:
+			return v, false
+		}
+	}
+	return v, false
+}
+
 type mapKeys []reflect.Value
 
 func (k mapKeys) Len() int {
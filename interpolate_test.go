@@ -1,6 +1,8 @@
 package dbr
 
 import (
+	"database/sql"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -148,6 +150,200 @@ func TestInterpolateForDialect(t *testing.T) {
 	}
 }
 
+type celsius float64
+
+func TestInterpolateDuration(t *testing.T) {
+	query, err := InterpolateForDialect("NOW() - ?", []interface{}{90 * time.Minute}, dialect.PostgreSQL)
+	assert.NoError(t, err)
+	assert.Equal(t, "NOW() - INTERVAL '5400000000 microseconds'", query)
+
+	// nanoseconds without interval literals
+	query, err = InterpolateForDialect("?", []interface{}{time.Second}, dialect.MySQL)
+	assert.NoError(t, err)
+	assert.Equal(t, "1000000000", query)
+
+	sess, fake := newFakeSession(dialect.PostgreSQL)
+	sess.DurationUnit = time.Second
+	d := 90 * time.Minute
+	_, err = sess.Select("a").From("t").Where(Gt("timeout", d)).Where(Lt("temperature", celsius(-3.5))).ReturnStrings()
+	assert.NoError(t, err)
+	_, err = sess.Select("a").From("t").Where(Gt("timeout", &d)).ReturnStrings()
+	assert.NoError(t, err)
+	stmts := fake.statements()
+	if assert.Len(t, stmts, 2) {
+		assert.Equal(t, `SELECT a FROM t WHERE ("timeout" > 5400) AND ("temperature" < -3.5)`, stmts[0].query)
+		assert.Equal(t, `SELECT a FROM t WHERE ("timeout" > 5400)`, stmts[1].query)
+	}
+}
+
+// tsRange is a driver specific type which is not a driver.Valuer
+type tsRange struct {
+	Lower, Upper time.Time
+}
+
+func TestInterpolateDisabled(t *testing.T) {
+	sess, fake := newFakeSession(dialect.PostgreSQL)
+	sess.DisableInterpolation = true
+
+	r := tsRange{Lower: time.Unix(0, 0), Upper: time.Unix(10, 0)}
+	_, err := sess.Select("a").From("t").
+		Where("r && ?", r).
+		Where(Eq("id", []int64{1, 2})).
+		Where(Expr("b = ?", Now)).
+		ReturnInt64s()
+	assert.NoError(t, err)
+
+	stmts := fake.statements()
+	if assert.Len(t, stmts, 1) {
+		assert.Equal(t, `SELECT a FROM t WHERE (r && $1) AND ("id" IN ($2,$3)) AND (b = $4)`, stmts[0].query)
+		assert.Equal(t, r, stmts[0].args[0])
+		assert.Equal(t, []interface{}{int64(1), int64(2)}, stmts[0].args[1:3])
+		// driver.Valuer is not evaluated by dbr
+		assert.Equal(t, Now, stmts[0].args[3])
+	}
+
+	// interpolated by default
+	sess.DisableInterpolation = false
+	_, err = sess.Select("a").From("t").Where("r && ?", r).ReturnInt64s()
+	assert.Equal(t, ErrNotSupported, err)
+}
+
+func TestInterpolateList(t *testing.T) {
+	one := 1
+	ids := []int{1, 2}
+	empty := []int{}
+	for _, test := range []struct {
+		cond  Builder
+		query string
+		bind  string
+		args  []interface{}
+	}{
+		{
+			cond:  Eq("id", []sql.NullInt64{{Int64: 1, Valid: true}, {}}),
+			query: "`id` IN (1,NULL)",
+			bind:  "`id` IN (?,?)",
+			args:  []interface{}{sql.NullInt64{Int64: 1, Valid: true}, sql.NullInt64{}},
+		},
+		{
+			cond:  Eq("id", []*int{&one, nil}),
+			query: "`id` IN (1,NULL)",
+			bind:  "`id` IN (?,?)",
+			args:  []interface{}{&one, (*int)(nil)},
+		},
+		{
+			cond:  Neq("id", &ids),
+			query: "`id` NOT IN (1,2)",
+			bind:  "`id` NOT IN (?,?)",
+			args:  []interface{}{1, 2},
+		},
+		{
+			cond:  Expr("id IN ?", []interface{}{[]int{1, 2}, 3}),
+			query: "id IN ((1,2),3)",
+			bind:  "id IN ((?,?),?)",
+			args:  []interface{}{1, 2, 3},
+		},
+		// []byte is a value
+		{
+			cond:  Eq("data", []byte("a")),
+			query: "`data` = ?",
+			bind:  "`data` = ?",
+			args:  []interface{}{[]byte("a")},
+		},
+		// empty lists are false for IN and true for NOT IN
+		{cond: Eq("id", []int{}), query: "0", bind: "0"},
+		{cond: Eq("id", []int(nil)), query: "0", bind: "0"},
+		{cond: Eq("id", &empty), query: "0", bind: "0"},
+		{cond: Neq("id", map[int]bool{}), query: "1", bind: "1"},
+	} {
+		sess, fake := newFakeSession(dialect.MySQL)
+		_, err := sess.DeleteFrom("t").Where(test.cond).Exec()
+		assert.NoError(t, err)
+
+		sess.DisableInterpolation = true
+		_, err = sess.DeleteFrom("t").Where(test.cond).Exec()
+		assert.NoError(t, err)
+
+		stmts := fake.statements()
+		if assert.Len(t, stmts, 2) {
+			assert.Equal(t, "DELETE FROM `t` WHERE ("+test.query+")", stmts[0].query)
+			assert.Equal(t, "DELETE FROM `t` WHERE ("+test.bind+")", stmts[1].query)
+			assert.Equal(t, test.args, stmts[1].args)
+		}
+	}
+
+	_, err := InterpolateForDialect("id IN ?", []interface{}{[]int{}}, dialect.MySQL)
+	assert.Equal(t, ErrInvalidSliceLength, err)
+}
+
+func TestWithPlaceholder(t *testing.T) {
+	d := WithPlaceholder(dialect.PostgreSQL, func(n int) string {
+		return "%" + strconv.Itoa(n+1)
+	})
+	sess, fake := newFakeSession(d)
+	sess.DisableInterpolation = true
+	_, err := sess.Select("a").From("t").
+		Where(Eq("id", []int64{1, 2})).
+		Where(Or(Eq("b", "x"), Gt("c", 3))).
+		ReturnInt64s()
+	assert.NoError(t, err)
+
+	// values are still encoded by the wrapped dialect
+	sess.DisableInterpolation = false
+	_, err = sess.Select("a").From("t").Where(Eq("b", "x")).ReturnInt64s()
+	assert.NoError(t, err)
+
+	stmts := fake.statements()
+	if assert.Len(t, stmts, 2) {
+		assert.Equal(t, `SELECT a FROM t WHERE ("id" IN (%1,%2)) AND (("b" = %3) OR ("c" > %4))`, stmts[0].query)
+		assert.Equal(t, []interface{}{int64(1), int64(2), "x", 3}, stmts[0].args)
+		assert.Equal(t, `SELECT a FROM t WHERE ("b" = 'x')`, stmts[1].query)
+	}
+
+	// wrappers can be combined in any order
+	for _, d := range []Dialect{MinimalParentheses(d), WithPlaceholder(MinimalParentheses(dialect.PostgreSQL), d.Placeholder)} {
+		i := interpolator{Buffer: NewBuffer(), Dialect: d, Bind: true}
+		err = i.interpolate("?", []interface{}{Or(Eq("b", "x"), And(Gt("c", 3), Lt("c", 5)))})
+		assert.NoError(t, err)
+		assert.Equal(t, `"b" = %1 OR "c" > %2 AND "c" < %3`, i.String())
+	}
+}
+
+func TestInterpolateStrict(t *testing.T) {
+	sess, fake := newFakeSession(dialect.MySQL)
+
+	_, err := sess.Update("t").Set("a", "x\x00y").Exec()
+	assert.NoError(t, err)
+	stmts := fake.statements()
+	if assert.Len(t, stmts, 1) {
+		assert.Equal(t, "UPDATE `t` SET `a` = 'x\\0y'", stmts[0].query)
+	}
+
+	sess.StrictInterpolation = true
+	_, err = sess.Update("t").Set("a", "x\x00y").Exec()
+	assert.Equal(t, ErrNulByte, err)
+	_, err = sess.Select("a").From("t").Where(Eq("a", []string{"x", "x\x00y"})).ReturnStrings()
+	assert.Equal(t, ErrNulByte, err)
+
+	sess.MaxValueSize = 3
+	_, err = sess.Update("t").Set("a", "xyz").Exec()
+	assert.NoError(t, err)
+	_, err = sess.Update("t").Set("a", "wxyz").Exec()
+	assert.Equal(t, ErrValueTooLarge, err)
+	_, err = sess.Update("t").Set("a", []byte("wxyz")).Exec()
+	assert.Equal(t, ErrValueTooLarge, err)
+	assert.Len(t, fake.statements(), 2)
+
+	// bound values are checked as well
+	sess.DisableInterpolation = true
+	_, err = sess.Update("t").Set("a", "x\x00y").Exec()
+	assert.Equal(t, ErrNulByte, err)
+	_, err = sess.Update("t").Set("a", "wxyz").Exec()
+	assert.Equal(t, ErrValueTooLarge, err)
+	_, err = sess.Update("t").Set("a", []byte("wxyz")).Exec()
+	assert.Equal(t, ErrValueTooLarge, err)
+	assert.Len(t, fake.statements(), 2)
+}
+
 // Attempts to test common SQL injection strings. See `InjectionAttempts` for
 // more information on the source and the strings themselves.
 func TestCommonSQLInjections(t *testing.T) {
@@ -0,0 +1,202 @@
+package dbr
+
+import (
+	"context"
+	"database/sql"
+	"reflect"
+)
+
+// RawIterator iterates over rows of a query exposing the raw bytes of their columns,
+// see SelectBuilder.IterateRaw. It must be closed unless Next returns false.
+type RawIterator struct {
+	rows   *sql.Rows
+	column []string
+	raw    []sql.RawBytes
+	dest   []interface{}
+	err    error
+}
+
+// IterateRaw executes the stmt and returns an iterator over the raw bytes of columns per row,
+// e.g. to parse values without allocating strings on a hot path.
+func (b *selectBuilder) IterateRaw() (*RawIterator, error) {
+	var it *RawIterator
+	err := queryRows(b.runner, b.EventReceiver, b, b.Dialect, func(rows *sql.Rows) error {
+		column, err := rows.Columns()
+		if err != nil {
+			rows.Close()
+			return err
+		}
+		it = &RawIterator{
+			rows:   rows,
+			column: column,
+			raw:    make([]sql.RawBytes, len(column)),
+			dest:   make([]interface{}, len(column)),
+		}
+		for i := range it.raw {
+			it.dest[i] = &it.raw[i]
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return it, nil
+}
+
+// Columns returns the column names
+func (it *RawIterator) Columns() []string {
+	return it.column
+}
+
+// Next scans the next row, it returns false after the last row or on error, see Err.
+// The rows are closed when Next returns false.
+func (it *RawIterator) Next() bool {
+	if it.err != nil || !it.rows.Next() {
+		it.rows.Close()
+		return false
+	}
+	it.err = it.rows.Scan(it.dest...)
+	if it.err != nil {
+		it.rows.Close()
+		return false
+	}
+	return true
+}
+
+// Row returns the raw bytes of the columns of the current row, nil for NULL.
+// The slice and the bytes are owned by the iterator and the driver: they are overwritten
+// by the next call of Next and invalid after Close, so copy what must be kept, e.g. with string(b).
+func (it *RawIterator) Row() []sql.RawBytes {
+	return it.raw
+}
+
+// Err returns the error of the iteration
+func (it *RawIterator) Err() error {
+	if it.err != nil {
+		return it.err
+	}
+	return it.rows.Err()
+}
+
+// Close closes the rows, it is safe to call it after Next returns false
+func (it *RawIterator) Close() error {
+	return it.rows.Close()
+}
+
+// Iterator iterates over rows of a query loading one row at a time, see SelectBuilder.Iterate.
+// It must be closed unless Next returns false.
+type Iterator struct {
+	ctx      context.Context
+	rows     *sql.Rows
+	column   []string
+	opts     loadOptions
+	timezone func(reflect.Value)
+	err      error
+}
+
+type queryContexter interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// contextRunner runs queries of runner with ctx instead of the context of the session
+type contextRunner struct {
+	runner
+	ctx context.Context
+}
+
+func (r contextRunner) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	if q, ok := r.runner.(queryContexter); ok {
+		return q.QueryContext(r.ctx, query, args...)
+	}
+	return r.runner.Query(query, args...)
+}
+
+func (r contextRunner) getContext() context.Context {
+	return r.ctx
+}
+
+// Iterate executes the stmt with ctx and returns an iterator loading the rows one at a time with Scan,
+// e.g. to process large results without loading them into memory.
+// Cancelling ctx stops the query: Next returns false, Err returns ctx.Err()
+// and the connection is released to the pool.
+func (b *selectBuilder) Iterate(ctx context.Context) (*Iterator, error) {
+	var it *Iterator
+	r := contextRunner{runner: b.runner, ctx: ctx}
+	err := queryRows(r, b.EventReceiver, b, b.Dialect, func(rows *sql.Rows) error {
+		column, err := rows.Columns()
+		if err != nil {
+			rows.Close()
+			return err
+		}
+		it = &Iterator{
+			ctx:    ctx,
+			rows:   rows,
+			column: column,
+			opts:   sessionLoadOptions(b.runner.getSession()),
+		}
+		if b.timezone != nil {
+			it.timezone = b.changeTimezone
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return it, nil
+}
+
+// Columns returns the column names
+func (it *Iterator) Columns() []string {
+	return it.column
+}
+
+// Next prepares the next row for Scan, it returns false after the last row, on error
+// or if the context is done, see Err. The rows are closed when Next returns false.
+func (it *Iterator) Next() bool {
+	if it.err == nil {
+		it.err = it.ctx.Err()
+	}
+	if it.err != nil || !it.rows.Next() {
+		it.rows.Close()
+		return false
+	}
+	return true
+}
+
+// Scan loads the current row into value like Load, e.g. a pointer to a struct, a map or a basic type
+func (it *Iterator) Scan(value interface{}) error {
+	v := reflect.ValueOf(value)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return ErrInvalidPointer
+	}
+	extractor, err := findExtractor(v.Elem().Type())
+	if err != nil {
+		return err
+	}
+	ptr := extractor(it.column, v.Elem())
+	err = it.opts.wrap(it.column, ptr, cryptColumns(v.Elem().Type()))
+	if err != nil {
+		return err
+	}
+	err = it.rows.Scan(ptr...)
+	if err != nil {
+		return err
+	}
+	if it.timezone != nil {
+		it.timezone(v)
+	}
+	return nil
+}
+
+// Err returns the error of the iteration, e.g. context.Canceled
+func (it *Iterator) Err() error {
+	if it.err != nil {
+		return it.err
+	}
+	return it.rows.Err()
+}
+
+// Close closes the rows releasing the connection, it is safe to call it after Next returns false
+func (it *Iterator) Close() error {
+	return it.rows.Close()
+}
@@ -0,0 +1,94 @@
+package dbr
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelectBuilderIterateRaw(t *testing.T) {
+	for _, sess := range testSession {
+		reset(sess)
+		prefix := fmt.Sprintf("raw_%d_", nextID())
+		_, err := sess.InsertInto("dbr_keys").Columns("key_value", "val_value").
+			Values(prefix+"1", "a").
+			Values(prefix+"2", "bb").
+			Values(prefix+"3", "ccc").
+			Exec()
+		assert.NoError(t, err)
+
+		it, err := sess.Select("key_value", "val_value").From("dbr_keys").
+			Where(Like("key_value", EscapeLike(prefix)+"%")).OrderAsc("key_value").IterateRaw()
+		if !assert.NoError(t, err) {
+			continue
+		}
+		assert.Equal(t, []string{"key_value", "val_value"}, it.Columns())
+
+		var keys, values []string
+		var first *[]byte
+		for it.Next() {
+			row := it.Row()
+			if first == nil {
+				first = (*[]byte)(&row[0])
+			}
+			// the slice is reused by every row
+			assert.True(t, first == (*[]byte)(&row[0]))
+			keys = append(keys, string(row[0]))
+			values = append(values, string(row[1]))
+		}
+		assert.NoError(t, it.Err())
+		assert.NoError(t, it.Close())
+		assert.Equal(t, []string{prefix + "1", prefix + "2", prefix + "3"}, keys)
+		assert.Equal(t, []string{"a", "bb", "ccc"}, values)
+	}
+}
+
+func TestSelectBuilderIterate(t *testing.T) {
+	for _, sess := range testSession {
+		reset(sess)
+		prefix := fmt.Sprintf("iter_%d_", nextID())
+		insert := sess.InsertInto("dbr_keys").Columns("key_value", "val_value")
+		for i := 0; i < 5; i++ {
+			insert.Values(fmt.Sprintf("%s%d", prefix, i), fmt.Sprint(i))
+		}
+		_, err := insert.Exec()
+		assert.NoError(t, err)
+		builder := sess.Select("key_value", "val_value").From("dbr_keys").
+			Where(Like("key_value", EscapeLike(prefix)+"%")).OrderAsc("key_value")
+
+		it, err := builder.Iterate(context.Background())
+		if !assert.NoError(t, err) {
+			continue
+		}
+		var rows []findOrCreateKey
+		for it.Next() {
+			var row findOrCreateKey
+			assert.NoError(t, it.Scan(&row))
+			rows = append(rows, row)
+		}
+		assert.NoError(t, it.Err())
+		assert.Len(t, rows, 5)
+		assert.Equal(t, findOrCreateKey{KeyValue: prefix + "4", ValValue: "4"}, rows[4])
+
+		// cancel mid-iteration
+		ctx, cancel := context.WithCancel(context.Background())
+		it, err = builder.Iterate(ctx)
+		if !assert.NoError(t, err) {
+			cancel()
+			continue
+		}
+		assert.True(t, it.Next())
+		var row findOrCreateKey
+		assert.NoError(t, it.Scan(&row))
+		assert.Equal(t, prefix+"0", row.KeyValue)
+		cancel()
+		assert.False(t, it.Next())
+		assert.False(t, it.Next())
+		assert.Equal(t, context.Canceled, it.Err())
+		assert.NoError(t, it.Close())
+		// the connection is back in the pool
+		assert.Equal(t, 0, sess.Stats().InUse)
+	}
+}
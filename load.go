@@ -4,16 +4,63 @@ import (
 	"database/sql"
 	"fmt"
 	"reflect"
+	"strconv"
 )
 
-// Load loads any value from sql.Rows
+// Load loads any value from sql.Rows.
+// Rows are appended to a slice, so existing elements are kept (e.g. to accumulate pages),
+// reset the slice before Load to replace them. Other values are overwritten by the first row.
+// It returns the number of loaded rows.
+// It returns ErrColumnMismatch if a column does not match a struct field, fields without a column are left unchanged.
+// See Session.IgnoreUnknownColumns to ignore such columns.
 func Load(rows *sql.Rows, value interface{}) (int, error) {
+	return load(rows, value, loadOptions{})
+}
+
+// loadOptions are the options of the session for loading, see Session.IgnoreUnknownColumns,
+// Session.NullAsZero and Session.Crypter
+type loadOptions struct {
+	ignoreUnknown bool
+	nullAsZero    bool
+	crypter       Crypter
+}
+
+func sessionLoadOptions(sess *Session) loadOptions {
+	return loadOptions{
+		ignoreUnknown: sess.IgnoreUnknownColumns,
+		nullAsZero:    sess.NullAsZero,
+		crypter:       sess.Crypter,
+	}
+}
+
+// wrap wraps the pointers of a row to apply the options
+func (o loadOptions) wrap(column []string, ptr []interface{}, crypt map[string]bool) error {
+	if len(crypt) > 0 {
+		err := cryptDest(column, ptr, crypt, o.crypter)
+		if err != nil {
+			return err
+		}
+	}
+	if o.nullAsZero {
+		for i := range ptr {
+			ptr[i] = nullAsZeroDest(ptr[i])
+		}
+	}
+	return nil
+}
+
+// load loads rows into value, extra are the destinations of the last columns of each row (e.g. see LoadPage)
+func load(rows *sql.Rows, value interface{}, opts loadOptions, extra ...interface{}) (int, error) {
 	defer rows.Close()
 
 	column, err := rows.Columns()
 	if err != nil {
 		return 0, err
 	}
+	if len(extra) > len(column) {
+		return 0, ErrDestinationCount
+	}
+	column = column[:len(column)-len(extra)]
 
 	v := reflect.ValueOf(value)
 	if v.Kind() != reflect.Ptr || v.IsNil() {
@@ -32,6 +79,13 @@ func Load(rows *sql.Rows, value interface{}) (int, error) {
 	if err != nil {
 		return count, err
 	}
+	if !opts.ignoreUnknown {
+		err = checkColumns(column, elemType)
+		if err != nil {
+			return count, err
+		}
+	}
+	crypt := cryptColumns(elemType)
 	for rows.Next() {
 		var elem reflect.Value
 		if isSlice {
@@ -40,7 +94,11 @@ func Load(rows *sql.Rows, value interface{}) (int, error) {
 			elem = v
 		}
 		ptr := extractor(column, elem)
-		err = rows.Scan(ptr...)
+		err = opts.wrap(column, ptr, crypt)
+		if err != nil {
+			return count, err
+		}
+		err = rows.Scan(append(ptr, extra...)...)
 		if err != nil {
 			return count, err
 		}
@@ -54,6 +112,107 @@ func Load(rows *sql.Rows, value interface{}) (int, error) {
 	return count, rows.Err()
 }
 
+// checkColumns returns ErrColumnMismatch if a column does not match a field of struct t
+func checkColumns(column []string, t reflect.Type) error {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || reflect.PtrTo(t).Implements(typeScanner) {
+		return nil
+	}
+	mapping := structMap(t)
+	for _, col := range column {
+		if _, ok := mapping[col]; !ok {
+			return ErrColumnMismatch
+		}
+	}
+	return nil
+}
+
+// nullZeroScanner scans NULL into field as its zero value, see Session.NullAsZero.
+// Other values are converted by the sql.Null* type of the kind of field.
+type nullZeroScanner struct {
+	field reflect.Value
+}
+
+func (s nullZeroScanner) Scan(v interface{}) error {
+	if v == nil {
+		s.field.Set(reflect.Zero(s.field.Type()))
+		return nil
+	}
+	switch s.field.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var n sql.NullInt64
+		err := n.Scan(v)
+		if err != nil {
+			return err
+		}
+		if s.field.OverflowInt(n.Int64) {
+			return fmt.Errorf("dbr: value %d overflows %v", n.Int64, s.field.Type())
+		}
+		s.field.SetInt(n.Int64)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		var n sql.NullString
+		err := n.Scan(v)
+		if err != nil {
+			return err
+		}
+		u, err := strconv.ParseUint(n.String, 10, s.field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		s.field.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		var n sql.NullFloat64
+		err := n.Scan(v)
+		if err != nil {
+			return err
+		}
+		s.field.SetFloat(n.Float64)
+	case reflect.Bool:
+		var n sql.NullBool
+		err := n.Scan(v)
+		if err != nil {
+			return err
+		}
+		s.field.SetBool(n.Bool)
+	case reflect.String:
+		var n sql.NullString
+		err := n.Scan(v)
+		if err != nil {
+			return err
+		}
+		s.field.SetString(n.String)
+	default:
+		// time.Time
+		var n NullTime
+		err := n.Scan(v)
+		if err != nil {
+			return err
+		}
+		s.field.Set(reflect.ValueOf(n.Time))
+	}
+	return nil
+}
+
+// nullAsZeroDest wraps ptr to scan NULL as zero value unless ptr can hold NULL
+func nullAsZeroDest(ptr interface{}) interface{} {
+	if _, ok := ptr.(sql.Scanner); ok {
+		return ptr
+	}
+	field := reflect.ValueOf(ptr).Elem()
+	switch field.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64, reflect.Bool, reflect.String:
+		return nullZeroScanner{field: field}
+	}
+	if field.Type() == typeTime {
+		return nullZeroScanner{field: field}
+	}
+	return ptr
+}
+
 type dummyScanner struct{}
 
 func (dummyScanner) Scan(interface{}) error {
@@ -0,0 +1,114 @@
+package dbr
+
+import (
+	"reflect"
+	"strings"
+)
+
+// LoadByKeys loads the rows of table having keyColumn in keys into dest with one query, see SelectBuilder.LoadByKeys
+func (sess *Session) LoadByKeys(table, keyColumn string, keys, dest interface{}) (int, error) {
+	return sess.Select("*").From(table).LoadByKeys(keyColumn, keys, dest)
+}
+
+// LoadByKeys loads the rows of table having keyColumn in keys into dest with one query, see SelectBuilder.LoadByKeys
+func (tx *Tx) LoadByKeys(table, keyColumn string, keys, dest interface{}) (int, error) {
+	return tx.Select("*").From(table).LoadByKeys(keyColumn, keys, dest)
+}
+
+// LoadByKeys adds `keyColumn IN keys` to the conditions and loads the rows with one query into dest,
+// a pointer to a map from key to a struct, e.g. *map[int64]User, or to a slice of structs, e.g. *map[int64][]Order,
+// to batch lookups of many keys (e.g. in GraphQL resolvers) instead of a query per key.
+// The key of a row is the field of the struct mapped to keyColumn, which must be selected;
+// it is the last row for a key if the map values are not slices. Keys without rows are not added to dest.
+// The builder is not changed, so it can be reused for the next batch of keys.
+// The map key must have the type of the field, or both must be numbers (e.g. int64 fields in map[int]...)
+// or strings, other types return ErrInvalidPointer.
+// It returns the number of loaded rows, no query is executed if keys is an empty slice.
+func (b *selectBuilder) LoadByKeys(keyColumn string, keys, dest interface{}) (int, error) {
+	if b.selectStmt.raw.Query != "" {
+		return 0, ErrNotSupported
+	}
+	v := reflect.ValueOf(dest)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Map {
+		return 0, ErrInvalidPointer
+	}
+	m := v.Elem()
+	elemType := m.Type().Elem()
+	isSlice := elemType.Kind() == reflect.Slice
+	if isSlice {
+		elemType = elemType.Elem()
+	}
+	structType := elemType
+	if structType.Kind() == reflect.Ptr {
+		structType = structType.Elem()
+	}
+	if structType.Kind() != reflect.Struct {
+		return 0, ErrNotStruct
+	}
+	column := keyColumn
+	if i := strings.LastIndexByte(column, '.'); i >= 0 {
+		column = column[i+1:]
+	}
+	index, ok := structMap(structType)[column]
+	if !ok {
+		return 0, ErrColumnNotSpecified
+	}
+	keyType := m.Type().Key()
+	if !isKeyConvertible(structType.FieldByIndex(index).Type, keyType) {
+		return 0, ErrInvalidPointer
+	}
+	if k := reflect.ValueOf(keys); k.Kind() == reflect.Slice && k.Len() == 0 {
+		return 0, nil
+	}
+
+	stmt := *b.selectStmt
+	stmt.WhereCond = append(stmt.WhereCond[:len(stmt.WhereCond):len(stmt.WhereCond)], Eq(keyColumn, keys))
+	batch := *b
+	batch.selectStmt = &stmt
+
+	rows := reflect.New(reflect.SliceOf(elemType))
+	count, err := batch.Load(rows.Interface())
+	if err != nil {
+		return count, err
+	}
+	if m.IsNil() {
+		m.Set(reflect.MakeMap(m.Type()))
+	}
+	rows = rows.Elem()
+	for i := 0; i < rows.Len(); i++ {
+		row := rows.Index(i)
+		key := reflect.Indirect(row).FieldByIndex(index).Convert(keyType)
+		if isSlice {
+			group := m.MapIndex(key)
+			if !group.IsValid() {
+				group = reflect.Zero(m.Type().Elem())
+			}
+			row = reflect.Append(group, row)
+		}
+		m.SetMapIndex(key, row)
+	}
+	return count, nil
+}
+
+// isKeyConvertible returns whether a field of type from is converted to a map key of type to,
+// conversions between numbers and strings (e.g. 65 to "A") are not allowed
+func isKeyConvertible(from, to reflect.Type) bool {
+	if from.AssignableTo(to) {
+		return true
+	}
+	if !from.ConvertibleTo(to) {
+		return false
+	}
+	return isNumberKind(from.Kind()) && isNumberKind(to.Kind()) ||
+		from.Kind() == reflect.String && to.Kind() == reflect.String
+}
+
+func isNumberKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,89 @@
+package dbr
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+type loadByKeysKey struct {
+	KeyValue string `db:"key_value"`
+	ValValue string `db:"val_value"`
+}
+
+func TestLoadByKeys(t *testing.T) {
+	for _, sess := range testSession {
+		prefix := fmt.Sprintf("by_keys_%d_", nextID())
+		keys := []string{prefix + "1", prefix + "2", prefix + "3", prefix + "missing"}
+		_, err := sess.InsertInto("dbr_keys").Columns("key_value", "val_value").
+			Values(keys[0], "a").Values(keys[1], "b").Values(keys[2], "c").Exec()
+		assert.NoError(t, err)
+
+		var rows map[string]loadByKeysKey
+		count, err := sess.LoadByKeys("dbr_keys", "key_value", keys, &rows)
+		assert.NoError(t, err)
+		assert.Equal(t, 3, count)
+		assert.Equal(t, map[string]loadByKeysKey{
+			keys[0]: {KeyValue: keys[0], ValValue: "a"},
+			keys[1]: {KeyValue: keys[1], ValValue: "b"},
+			keys[2]: {KeyValue: keys[2], ValValue: "c"},
+		}, rows)
+
+		// extra conditions are kept
+		builder := sess.Select("*").From("dbr_keys").Where(Neq("val_value", "b"))
+		rows = nil
+		count, err = builder.LoadByKeys("key_value", keys, &rows)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, count)
+		assert.Len(t, rows, 2)
+		assert.Equal(t, "c", rows[keys[2]].ValValue)
+
+		var ptrs map[string]*loadByKeysKey
+		count, err = builder.LoadByKeys("key_value", keys[:1], &ptrs)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, count)
+		assert.Equal(t, map[string]*loadByKeysKey{keys[0]: {KeyValue: keys[0], ValValue: "a"}}, ptrs)
+	}
+}
+
+func TestLoadByKeysGroup(t *testing.T) {
+	type order struct {
+		ID     int64
+		UserID int64
+	}
+	sess, dbmock := newSessionMock()
+	dbmock.ExpectQuery(regexp.QuoteMeta("SELECT id, user_id FROM orders WHERE (`paid` = 1) AND (`orders`.`user_id` IN (1,2,3))")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id"}).AddRow(10, 1).AddRow(11, 2).AddRow(12, 1))
+
+	orders := map[int][]order{}
+	count, err := sess.Select("id", "user_id").From("orders").Where(Eq("paid", true)).
+		LoadByKeys("orders.user_id", []int{1, 2, 3}, &orders)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, count)
+	assert.Equal(t, map[int][]order{
+		1: {{ID: 10, UserID: 1}, {ID: 12, UserID: 1}},
+		2: {{ID: 11, UserID: 2}},
+	}, orders)
+
+	// no query for empty keys
+	count, err = sess.Select("*").From("orders").LoadByKeys("user_id", []int{}, &orders)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, count)
+	assert.NoError(t, dbmock.ExpectationsWereMet())
+
+	_, err = sess.Select("*").From("orders").LoadByKeys("missing", []int{1}, &orders)
+	assert.Equal(t, ErrColumnNotSpecified, err)
+	_, err = sess.Select("*").From("orders").LoadByKeys("user_id", []int{1}, orders)
+	assert.Equal(t, ErrInvalidPointer, err)
+
+	// int64 keys are not converted to strings
+	var byString map[string][]order
+	_, err = sess.Select("*").From("orders").LoadByKeys("user_id", []int{65}, &byString)
+	assert.Equal(t, ErrInvalidPointer, err)
+	var byInt map[int]loadByKeysKey
+	_, err = sess.Select("*").From("dbr_keys").LoadByKeys("key_value", []int{65}, &byInt)
+	assert.Equal(t, ErrInvalidPointer, err)
+}
@@ -59,6 +59,71 @@ func TestLoadWithBytesValue(t *testing.T) {
 		reflect.Indirect(reflect.ValueOf(v)).Interface())
 }
 
+func TestLoadAppend(t *testing.T) {
+	type testStruct struct {
+		A int
+	}
+	session, dbmock := newSessionMock()
+	dbmock.ExpectQuery("SELECT a FROM table LIMIT 0,2").WillReturnRows(sqlmock.NewRows([]string{"a"}).AddRow(1).AddRow(2))
+	dbmock.ExpectQuery("SELECT a FROM table LIMIT 2,2").WillReturnRows(sqlmock.NewRows([]string{"a"}).AddRow(3))
+
+	var v []testStruct
+	count, err := session.Select("a").From("table").Paginate(1, 2).LoadStructs(&v)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+	count, err = session.Select("a").From("table").Paginate(2, 2).LoadStructs(&v)
+	assert.NoError(t, err)
+	// count is the number of rows loaded by the call
+	assert.Equal(t, 1, count)
+	assert.Equal(t, []testStruct{{1}, {2}, {3}}, v)
+	assert.NoError(t, dbmock.ExpectationsWereMet())
+}
+
+func TestLoadColumnMismatch(t *testing.T) {
+	type testStruct struct {
+		A string
+		B string
+		C string
+	}
+	session, dbmock := newSessionMock()
+
+	// subset, c is left unchanged
+	dbmock.ExpectQuery("SELECT a, b FROM table").WillReturnRows(sqlmock.NewRows([]string{"a", "b"}).AddRow("a", "b"))
+	v := testStruct{C: "c"}
+	err := session.Select("a", "b").From("table").LoadStruct(&v)
+	assert.NoError(t, err)
+	assert.Equal(t, testStruct{"a", "b", "c"}, v)
+
+	// superset fails by default
+	dbmock.ExpectQuery("SELECT a, b, c, d FROM table").WillReturnRows(sqlmock.NewRows([]string{"a", "b", "c", "d"}).AddRow("a", "b", "c", "d"))
+	var vs []testStruct
+	_, err = session.Select("a", "b", "c", "d").From("table").LoadStructs(&vs)
+	assert.Equal(t, ErrColumnMismatch, err)
+	assert.Empty(t, vs)
+
+	// d is ignored in tolerant mode
+	session.IgnoreUnknownColumns = true
+	dbmock.ExpectQuery("SELECT a, b, c, d FROM table").WillReturnRows(sqlmock.NewRows([]string{"a", "b", "c", "d"}).AddRow("a", "b", "c", "d"))
+	_, err = session.Select("a", "b", "c", "d").From("table").LoadStructs(&vs)
+	assert.NoError(t, err)
+	assert.Equal(t, []testStruct{{"a", "b", "c"}}, vs)
+
+	dbmock.ExpectQuery("SELECT a FROM table").WillReturnRows(sqlmock.NewRows([]string{"a"}).AddRow("a"))
+	v = testStruct{}
+	err = session.Select("a").From("table").LoadStruct(&v)
+	assert.NoError(t, err)
+	assert.Equal(t, testStruct{A: "a"}, v)
+	session.IgnoreUnknownColumns = false
+
+	// values are not checked
+	dbmock.ExpectQuery("SELECT d FROM table").WillReturnRows(sqlmock.NewRows([]string{"d"}).AddRow("d"))
+	var s string
+	err = session.Select("d").From("table").LoadValue(&s)
+	assert.NoError(t, err)
+	assert.Equal(t, "d", s)
+	assert.NoError(t, dbmock.ExpectationsWereMet())
+}
+
 func BenchmarkLoad(b *testing.B) {
 	session, dbmock := newSessionMock()
 	rows := sqlmock.NewRows([]string{"a", "b", "c"})
@@ -84,7 +149,7 @@ func BenchmarkLoad(b *testing.B) {
 	}
 }
 
-func newSessionMock() (SessionRunner, sqlmock.Sqlmock) {
+func newSessionMock() (*Session, sqlmock.Sqlmock) {
 	db, m, err := sqlmock.New()
 	if err != nil {
 		panic(err)
@@ -92,3 +157,41 @@ func newSessionMock() (SessionRunner, sqlmock.Sqlmock) {
 	conn := Connection{DB: db, Dialect: dialect.MySQL, EventReceiver: nullReceiver}
 	return conn.NewSession(nil), m
 }
+
+func TestLoadNullAsZero(t *testing.T) {
+	type nullable struct {
+		A int
+		B string
+		C *int
+		D NullString
+	}
+	columns := []string{"a", "b", "c", "d"}
+
+	sess, dbmock := newSessionMock()
+	dbmock.ExpectQuery("SELECT .+").WillReturnRows(sqlmock.NewRows(columns).AddRow(nil, nil, nil, nil))
+	var strict nullable
+	_, err := sess.Select("*").From("t").Load(&strict)
+	assert.Error(t, err)
+
+	sess.NullAsZero = true
+	one := 1
+	dbmock.ExpectQuery("SELECT .+").WillReturnRows(sqlmock.NewRows(columns).
+		AddRow(nil, nil, nil, nil).
+		AddRow(int64(2), "b", int64(3), "d"))
+	value := []nullable{{A: 5, B: "x", C: &one, D: NewNullString("y")}}
+	_, err = sess.Select("*").From("t").Load(&value)
+	assert.NoError(t, err)
+	three := 3
+	assert.Equal(t, []nullable{
+		{A: 5, B: "x", C: &one, D: NewNullString("y")},
+		{},
+		{A: 2, B: "b", C: &three, D: NewNullString("d")},
+	}, value)
+
+	dbmock.ExpectQuery("SELECT .+").WillReturnRows(sqlmock.NewRows([]string{"a"}).AddRow(nil))
+	n := 7
+	err = sess.Select("a").From("t").LoadValue(&n)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, n)
+	assert.NoError(t, dbmock.ExpectationsWereMet())
+}
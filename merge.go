@@ -0,0 +1,275 @@
+package dbr
+
+import "sort"
+
+// MergeStmt builds `MERGE INTO ...`
+type MergeStmt interface {
+	Builder
+
+	Using(source interface{}, alias string) MergeStmt
+	UsingValues(alias string, column []string, value ...[]interface{}) MergeStmt
+	On(query interface{}, value ...interface{}) MergeStmt
+	WhenMatched(set map[string]interface{}) MergeStmt
+	WhenMatchedDelete() MergeStmt
+	WhenNotMatched(column []string, value ...interface{}) MergeStmt
+}
+
+type mergeStmt struct {
+	Table string
+
+	Source       interface{}
+	SourceAlias  string
+	SourceColumn []string
+	SourceValue  [][]interface{}
+
+	OnCond []Builder
+
+	MatchedColumn   []string
+	MatchedValue    map[string]interface{}
+	IsMatchedDelete bool
+
+	InsertColumn []string
+	InsertValue  []interface{}
+}
+
+// Build builds `MERGE INTO ...` in dialect
+func (b *mergeStmt) Build(d Dialect, buf Buffer) error {
+	m, ok := baseDialect(d).(MergeDialect)
+	if !ok || !m.SupportsMerge() {
+		return ErrNotSupported
+	}
+	if b.Table == "" || (b.Source == nil && len(b.SourceValue) == 0) {
+		return ErrTableNotSpecified
+	}
+	if len(b.OnCond) == 0 {
+		return ErrCondNotSpecified
+	}
+	if len(b.MatchedColumn) == 0 && !b.IsMatchedDelete && len(b.InsertColumn) == 0 {
+		return ErrColumnNotSpecified
+	}
+	if len(b.MatchedColumn) == 0 && b.IsMatchedDelete && !m.SupportsMatchedDelete() {
+		return ErrNotSupported
+	}
+
+	buf.WriteString("MERGE INTO ")
+	buf.WriteString(d.QuoteIdent(b.Table))
+
+	// AS is optional for the source alias in PostgreSQL and not allowed in Oracle
+	buf.WriteString(" USING ")
+	if len(b.SourceValue) > 0 {
+		err := b.buildValues(d, m.Dual(), buf)
+		if err != nil {
+			return err
+		}
+	} else {
+		writeTable(d, buf, b.Source)
+		if b.SourceAlias != "" {
+			buf.WriteString(" ")
+			buf.WriteString(d.QuoteIdent(b.SourceAlias))
+		}
+	}
+
+	// Oracle requires the condition in parentheses
+	buf.WriteString(" ON ")
+	paren := len(b.OnCond) > 1 || isMinimalParentheses(d)
+	if paren {
+		buf.WriteString("(")
+	}
+	err := And(b.OnCond...).Build(d, buf)
+	if err != nil {
+		return err
+	}
+	if paren {
+		buf.WriteString(")")
+	}
+
+	if len(b.MatchedColumn) > 0 {
+		buf.WriteString(" WHEN MATCHED THEN UPDATE SET ")
+		for i, col := range b.MatchedColumn {
+			if i > 0 {
+				buf.WriteString(", ")
+			}
+			buf.WriteString(d.QuoteIdent(col))
+			buf.WriteString(" = ")
+			err := buildValue(d, buf, b.MatchedValue[col])
+			if err != nil {
+				return err
+			}
+		}
+	} else if b.IsMatchedDelete {
+		buf.WriteString(" WHEN MATCHED THEN DELETE")
+	}
+
+	if len(b.InsertColumn) > 0 {
+		value := b.InsertValue
+		if len(value) == 0 {
+			for _, col := range b.InsertColumn {
+				value = append(value, I(b.sourceName()+"."+col))
+			}
+		}
+		if len(value) != len(b.InsertColumn) {
+			return ErrTupleLength
+		}
+		buf.WriteString(" WHEN NOT MATCHED THEN INSERT (")
+		for i, col := range b.InsertColumn {
+			if i > 0 {
+				buf.WriteString(",")
+			}
+			buf.WriteString(d.QuoteIdent(col))
+		}
+		buf.WriteString(") VALUES (")
+		for i, v := range value {
+			if i > 0 {
+				buf.WriteString(",")
+			}
+			err := buildValue(d, buf, v)
+			if err != nil {
+				return err
+			}
+		}
+		buf.WriteString(")")
+	}
+	return nil
+}
+
+// sourceName is the alias of the source, or its name for a table without alias
+func (b *mergeStmt) sourceName() string {
+	if table, ok := b.Source.(string); ok && b.SourceAlias == "" {
+		return table
+	}
+	return b.SourceAlias
+}
+
+// Merge creates a MergeStmt merging rows into table,
+// supported by PostgreSQL 15+ and Oracle, Build returns ErrNotSupported in other dialects
+func Merge(table string) MergeStmt {
+	return createMergeStmt(table)
+}
+
+func createMergeStmt(table string) *mergeStmt {
+	return &mergeStmt{
+		Table:        table,
+		MatchedValue: make(map[string]interface{}),
+	}
+}
+
+// Using sets the source rows, a table name or a subquery (e.g. a SelectStmt) with alias
+func (b *mergeStmt) Using(source interface{}, alias string) MergeStmt {
+	b.Source = source
+	b.SourceAlias = alias
+	b.SourceValue = nil
+	return b
+}
+
+// buildValues writes the source rows, `(VALUES (...), ...) alias(column, ...)`,
+// or `(SELECT ... column, ... FROM dual UNION ALL SELECT ...) alias` in dialects with a dual table (Oracle)
+func (b *mergeStmt) buildValues(d Dialect, dual string, buf Buffer) error {
+	if dual == "" {
+		buf.WriteString("(VALUES ")
+	} else {
+		buf.WriteString("(")
+	}
+	for i, tuple := range b.SourceValue {
+		if len(tuple) != len(b.SourceColumn) {
+			return ErrTupleLength
+		}
+		switch {
+		case dual == "" && i > 0:
+			buf.WriteString(", (")
+		case dual == "":
+			buf.WriteString("(")
+		case i > 0:
+			buf.WriteString(" UNION ALL SELECT ")
+		default:
+			buf.WriteString("SELECT ")
+		}
+		for j, v := range tuple {
+			if j > 0 {
+				buf.WriteString(",")
+			}
+			err := buildValue(d, buf, v)
+			if err != nil {
+				return err
+			}
+			if dual != "" && i == 0 {
+				// the first select names the columns
+				buf.WriteString(" ")
+				buf.WriteString(d.QuoteIdent(b.SourceColumn[j]))
+			}
+		}
+		if dual == "" {
+			buf.WriteString(")")
+		} else {
+			buf.WriteString(" FROM ")
+			buf.WriteString(dual)
+		}
+	}
+	buf.WriteString(") ")
+	buf.WriteString(d.QuoteIdent(b.SourceAlias))
+	if dual != "" {
+		return nil
+	}
+	buf.WriteString("(")
+	for i, col := range b.SourceColumn {
+		if i > 0 {
+			buf.WriteString(",")
+		}
+		buf.WriteString(d.QuoteIdent(col))
+	}
+	buf.WriteString(")")
+	return nil
+}
+
+// UsingValues sets the source rows to a VALUES list, `(VALUES (...), ...) alias(column, ...)`,
+// or selects of the rows FROM dual joined with UNION ALL in Oracle.
+// Each tuple must have a value for each column.
+func (b *mergeStmt) UsingValues(alias string, column []string, value ...[]interface{}) MergeStmt {
+	b.Source = nil
+	b.SourceAlias = alias
+	b.SourceColumn = column
+	b.SourceValue = value
+	return b
+}
+
+// On adds a condition matching source rows to rows of the table, e.g. On("t.id = s.id")
+func (b *mergeStmt) On(query interface{}, value ...interface{}) MergeStmt {
+	switch query := query.(type) {
+	case string:
+		b.OnCond = append(b.OnCond, Expr(query, value...))
+	case Builder:
+		b.OnCond = append(b.OnCond, query)
+	}
+	return b
+}
+
+// WhenMatched adds `WHEN MATCHED THEN UPDATE SET ...` updating the matched rows,
+// values can reference the source with I, e.g. map[string]interface{}{"name": I("s.name")}
+func (b *mergeStmt) WhenMatched(set map[string]interface{}) MergeStmt {
+	column := make([]string, 0, len(set))
+	for col := range set {
+		column = append(column, col)
+	}
+	sort.Strings(column)
+	for _, col := range column {
+		if _, ok := b.MatchedValue[col]; !ok {
+			b.MatchedColumn = append(b.MatchedColumn, col)
+		}
+		b.MatchedValue[col] = set[col]
+	}
+	return b
+}
+
+// WhenMatchedDelete adds `WHEN MATCHED THEN DELETE` deleting the matched rows, it is ignored after WhenMatched.
+// Build returns ErrNotSupported in Oracle, which only deletes rows updated by WHEN MATCHED THEN UPDATE.
+func (b *mergeStmt) WhenMatchedDelete() MergeStmt {
+	b.IsMatchedDelete = true
+	return b
+}
+
+// WhenNotMatched adds `WHEN NOT MATCHED THEN INSERT (column, ...) VALUES (value, ...)` inserting the source rows
+// without a match. Without values the columns of the same name of the source are inserted.
+func (b *mergeStmt) WhenNotMatched(column []string, value ...interface{}) MergeStmt {
+	b.InsertColumn = column
+	b.InsertValue = value
+	return b
+}
@@ -0,0 +1,90 @@
+package dbr
+
+import "database/sql"
+
+// MergeBuilder builds `MERGE INTO ...`
+type MergeBuilder interface {
+	Builder
+	EventReceiver
+	Executer
+
+	Using(source interface{}, alias string) MergeBuilder
+	UsingValues(alias string, column []string, value ...[]interface{}) MergeBuilder
+	On(query interface{}, value ...interface{}) MergeBuilder
+	WhenMatched(set map[string]interface{}) MergeBuilder
+	WhenMatchedDelete() MergeBuilder
+	WhenNotMatched(column []string, value ...interface{}) MergeBuilder
+}
+
+type mergeBuilder struct {
+	runner
+	EventReceiver
+
+	Dialect   Dialect
+	mergeStmt *mergeStmt
+}
+
+// Merge creates a MergeBuilder
+func (sess *Session) Merge(table string) MergeBuilder {
+	return &mergeBuilder{
+		runner:        sess,
+		EventReceiver: sess,
+		Dialect:       sess.Dialect,
+		mergeStmt:     createMergeStmt(table),
+	}
+}
+
+// Merge creates a MergeBuilder
+func (tx *Tx) Merge(table string) MergeBuilder {
+	return &mergeBuilder{
+		runner:        tx,
+		EventReceiver: tx,
+		Dialect:       tx.Dialect,
+		mergeStmt:     createMergeStmt(table),
+	}
+}
+
+// Exec executes the stmt
+func (b *mergeBuilder) Exec() (sql.Result, error) {
+	return exec(b.runner, b.EventReceiver, b, b.Dialect)
+}
+
+func (b *mergeBuilder) Build(d Dialect, buf Buffer) error {
+	return b.mergeStmt.Build(d, buf)
+}
+
+// Using sets the source rows, see MergeStmt.Using
+func (b *mergeBuilder) Using(source interface{}, alias string) MergeBuilder {
+	b.mergeStmt.Using(source, alias)
+	return b
+}
+
+// UsingValues sets the source rows to a VALUES list, see MergeStmt.UsingValues
+func (b *mergeBuilder) UsingValues(alias string, column []string, value ...[]interface{}) MergeBuilder {
+	b.mergeStmt.UsingValues(alias, column, value...)
+	return b
+}
+
+// On adds a condition matching source rows to rows of the table
+func (b *mergeBuilder) On(query interface{}, value ...interface{}) MergeBuilder {
+	b.mergeStmt.On(query, value...)
+	return b
+}
+
+// WhenMatched updates the matched rows, see MergeStmt.WhenMatched
+func (b *mergeBuilder) WhenMatched(set map[string]interface{}) MergeBuilder {
+	b.mergeStmt.WhenMatched(set)
+	return b
+}
+
+// WhenMatchedDelete deletes the matched rows
+func (b *mergeBuilder) WhenMatchedDelete() MergeBuilder {
+	b.mergeStmt.WhenMatchedDelete()
+	return b
+}
+
+// WhenNotMatched inserts the source rows without a match, see MergeStmt.WhenNotMatched
+func (b *mergeBuilder) WhenNotMatched(column []string, value ...interface{}) MergeBuilder {
+	b.mergeStmt.WhenNotMatched(column, value...)
+	return b
+}
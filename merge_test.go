@@ -0,0 +1,108 @@
+package dbr
+
+import (
+	"testing"
+
+	"github.com/lianchengwu/dbr/dialect"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeStmt(t *testing.T) {
+	for _, test := range []struct {
+		stmt    MergeStmt
+		dialect Dialect
+		query   string
+		err     error
+	}{
+		{
+			stmt: Merge("users").
+				UsingValues("s", []string{"id", "name"}, []interface{}{1, "one"}, []interface{}{2, "two"}).
+				On("users.id = s.id").
+				WhenMatched(map[string]interface{}{"name": I("s.name")}).
+				WhenNotMatched([]string{"id", "name"}),
+			dialect: dialect.PostgreSQL,
+			query: `MERGE INTO "users" USING (VALUES (1,'one'), (2,'two')) "s"("id","name") ON (users.id = s.id) ` +
+				`WHEN MATCHED THEN UPDATE SET "name" = "s"."name" ` +
+				`WHEN NOT MATCHED THEN INSERT ("id","name") VALUES ("s"."id","s"."name")`,
+		},
+		{
+			stmt: Merge("users").
+				UsingValues("s", []string{"id", "name"}, []interface{}{1, "one"}, []interface{}{2, "two"}).
+				On("users.id = s.id").
+				WhenMatched(map[string]interface{}{"name": I("s.name")}),
+			dialect: dialect.Oracle,
+			query: `MERGE INTO "users" USING (SELECT 1 "id",'one' "name" FROM dual UNION ALL SELECT 2,'two' FROM dual) "s" ` +
+				`ON (users.id = s.id) WHEN MATCHED THEN UPDATE SET "name" = "s"."name"`,
+		},
+		{
+			stmt: Merge("users").
+				Using(Select("id").From("new_users").Where(Eq("active", true)), "s").
+				On("users.id = s.id").
+				On(Eq("users.locked", false)).
+				WhenNotMatched([]string{"id", "created_at"}, I("s.id"), Expr("SYSDATE")),
+			dialect: dialect.Oracle,
+			query: `MERGE INTO "users" USING (SELECT id FROM new_users WHERE ("active" = 1)) "s" ` +
+				`ON ((users.id = s.id) AND ("users"."locked" = 0)) ` +
+				`WHEN NOT MATCHED THEN INSERT ("id","created_at") VALUES ("s"."id",SYSDATE)`,
+		},
+		{
+			stmt:    Merge("users").Using("new_users", "s").On("users.id = s.id").WhenMatchedDelete(),
+			dialect: dialect.PostgreSQL,
+			query:   `MERGE INTO "users" USING "new_users" "s" ON (users.id = s.id) WHEN MATCHED THEN DELETE`,
+		},
+		{
+			stmt:    Merge("users").Using("new_users", "s").On("users.id = s.id").WhenMatchedDelete(),
+			dialect: dialect.Oracle,
+			err:     ErrNotSupported,
+		},
+		{
+			stmt:    Merge("users").Using("staged", "").On("users.id = staged.id").WhenNotMatched([]string{"id"}),
+			dialect: MinimalParentheses(dialect.PostgreSQL),
+			query:   `MERGE INTO "users" USING "staged" ON (users.id = staged.id) WHEN NOT MATCHED THEN INSERT ("id") VALUES ("staged"."id")`,
+		},
+		{
+			stmt:    Merge("users").Using("staged", "s").On("users.id = s.id").WhenNotMatched([]string{"id"}),
+			dialect: dialect.MySQL,
+			err:     ErrNotSupported,
+		},
+		{
+			stmt:    Merge("users").Using("staged", "s").WhenNotMatched([]string{"id"}),
+			dialect: dialect.PostgreSQL,
+			err:     ErrCondNotSpecified,
+		},
+		{
+			stmt:    Merge("users").Using("staged", "s").On("users.id = s.id"),
+			dialect: dialect.PostgreSQL,
+			err:     ErrColumnNotSpecified,
+		},
+		{
+			stmt:    Merge("users").UsingValues("s", []string{"id", "name"}, []interface{}{1}).On("users.id = s.id").WhenMatchedDelete(),
+			dialect: dialect.PostgreSQL,
+			err:     ErrTupleLength,
+		},
+	} {
+		buf := NewBuffer()
+		err := test.stmt.Build(test.dialect, buf)
+		assert.Equal(t, test.err, err)
+		if err != nil {
+			continue
+		}
+		query, err := InterpolateForDialect(buf.String(), buf.Value(), test.dialect)
+		assert.NoError(t, err)
+		assert.Equal(t, test.query, query)
+	}
+}
+
+func TestMergeBuilder(t *testing.T) {
+	sess, fake := newFakeSession(dialect.PostgreSQL)
+	_, err := sess.Merge("users").
+		UsingValues("s", []string{"id"}, []interface{}{1}).
+		On("users.id = s.id").
+		WhenMatched(map[string]interface{}{"seen": true}).
+		Exec()
+	assert.NoError(t, err)
+	stmts := fake.statements()
+	if assert.Len(t, stmts, 1) {
+		assert.Equal(t, `MERGE INTO "users" USING (VALUES (1)) "s"("id") ON (users.id = s.id) WHEN MATCHED THEN UPDATE SET "seen" = TRUE`, stmts[0].query)
+	}
+}
@@ -1,5 +1,7 @@
 package dbr
 
+import "strings"
+
 type direction bool
 
 // orderby directions
@@ -9,16 +11,51 @@ const (
 	desc           = true
 )
 
+// orderColumn orders by column, SelectStmt quotes it as a reference to the output column
+// if it is an alias of the selected columns, see As
+type orderColumn struct {
+	column string
+	dir    string
+}
+
 func order(column string, dir direction) Builder {
-	return BuildFunc(func(d Dialect, buf Buffer) error {
-		// FIXME: no quote ident
-		buf.WriteString(column)
-		switch dir {
-		case asc:
-			buf.WriteString(" ASC")
-		case desc:
-			buf.WriteString(" DESC")
-		}
-		return nil
-	})
+	switch dir {
+	case desc:
+		return &orderColumn{column: column, dir: " DESC"}
+	default:
+		return &orderColumn{column: column, dir: " ASC"}
+	}
+}
+
+func (o *orderColumn) Build(d Dialect, buf Buffer) error {
+	if o.dir == "" {
+		// raw ordering of OrderBy
+		return Expr(o.column).Build(d, buf)
+	}
+	// FIXME: no quote ident
+	buf.WriteString(o.column)
+	buf.WriteString(o.dir)
+	return nil
+}
+
+func (o *orderColumn) buildAlias(d Dialect, buf Buffer) {
+	buf.WriteString(d.QuoteIdent(o.column))
+	buf.WriteString(o.dir)
+}
+
+// OrderByWhitelist builds ordering for the field received from untrusted input (e.g. a query parameter).
+// The field is mapped to a column via allowed, dir must be "asc", "desc" or empty (asc), case insensitive.
+// It returns ErrOrderNotAllowed if field is not in allowed and ErrInvalidDirection if dir is invalid.
+func OrderByWhitelist(field, dir string, allowed map[string]string) (Builder, error) {
+	column, ok := allowed[field]
+	if !ok {
+		return nil, ErrOrderNotAllowed
+	}
+	switch strings.ToLower(dir) {
+	case "", "asc":
+		return order(column, asc), nil
+	case "desc":
+		return order(column, desc), nil
+	}
+	return nil, ErrInvalidDirection
 }
@@ -0,0 +1,39 @@
+package dbr
+
+import (
+	"testing"
+
+	"github.com/lianchengwu/dbr/dialect"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrderByWhitelist(t *testing.T) {
+	allowed := map[string]string{
+		"name":    "users.name",
+		"created": "users.created_at",
+	}
+	for _, test := range []struct {
+		field, dir string
+		query      string
+		err        error
+	}{
+		{field: "name", dir: "", query: "SELECT * FROM users ORDER BY users.name ASC"},
+		{field: "created", dir: "DESC", query: "SELECT * FROM users ORDER BY users.created_at DESC"},
+		{field: "password", dir: "asc", err: ErrOrderNotAllowed},
+		{field: "users.name", dir: "asc", err: ErrOrderNotAllowed},
+		{field: "name", dir: "asc; DROP TABLE users", err: ErrInvalidDirection},
+	} {
+		order, err := OrderByWhitelist(test.field, test.dir, allowed)
+		assert.Equal(t, test.err, err)
+		if err != nil {
+			continue
+		}
+		buf := NewBuffer()
+		err = Select("*").From("users").OrderBy(order).Build(dialect.MySQL, buf)
+		assert.NoError(t, err)
+		assert.Equal(t, test.query, buf.String())
+	}
+
+	err := Select("*").From("users").OrderBy(1).Build(dialect.MySQL, NewBuffer())
+	assert.Equal(t, ErrInvalidOrder, err)
+}
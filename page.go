@@ -0,0 +1,71 @@
+package dbr
+
+import (
+	"database/sql"
+	"reflect"
+)
+
+// Page is the result of LoadPage
+type Page struct {
+	// Count is the number of loaded rows
+	Count int
+	// Total is the number of rows of the query ignoring LIMIT and OFFSET
+	Total int64
+}
+
+const pageTotalColumn = "dbr_total"
+
+// LoadPage loads the rows of the page (see Paginate) into value, a pointer to a slice,
+// and returns the number of loaded rows and the total number of rows of the query ignoring LIMIT and OFFSET.
+// The total is a field of the returned Page rather than of a struct holding the rows,
+// so the rows can be loaded into any slice like Load.
+// Dialects with window functions count in the same query with `COUNT(*) OVER ()`,
+// others, DISTINCT statements and FOR UPDATE (which PostgreSQL does not allow with window functions)
+// count with a second query like Count.
+// A page past the last row is counted with a second query as it has no rows.
+// It returns ErrNotSupported for raw queries.
+func (b *selectBuilder) LoadPage(value interface{}) (Page, error) {
+	if b.selectStmt.raw.Query != "" {
+		return Page{}, ErrNotSupported
+	}
+	v := reflect.ValueOf(value)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Slice {
+		return Page{}, ErrInvalidPointer
+	}
+	if w, ok := baseDialect(b.Dialect).(WindowDialect); !ok || !w.SupportsWindow() ||
+		b.selectStmt.IsDistinct || b.selectStmt.IsForUpdate {
+		count, err := b.Load(value)
+		if err != nil {
+			return Page{}, err
+		}
+		total, err := b.Count()
+		if err != nil {
+			return Page{}, err
+		}
+		return Page{Count: count, Total: total}, nil
+	}
+
+	stmt := *b.selectStmt
+	stmt.Column = append(stmt.Column[:len(stmt.Column):len(stmt.Column)],
+		As(Expr("COUNT(*) OVER ()"), pageTotalColumn))
+	var page Page
+	// BuildFunc prevents top level statement from being parenthesized as subquery
+	err := queryRows(b.runner, b.EventReceiver, BuildFunc(stmt.Build), b.Dialect, func(rows *sql.Rows) error {
+		var err error
+		page.Count, err = load(rows, value, sessionLoadOptions(b.runner.getSession()), &page.Total)
+		return err
+	})
+	if err != nil {
+		return Page{}, err
+	}
+	if b.timezone != nil {
+		b.changeTimezone(reflect.ValueOf(value))
+	}
+	if page.Count == 0 && stmt.OffsetCount > 0 {
+		page.Total, err = b.Count()
+		if err != nil {
+			return Page{}, err
+		}
+	}
+	return page, nil
+}
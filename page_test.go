@@ -0,0 +1,90 @@
+package dbr
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lianchengwu/dbr/dialect"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadPage(t *testing.T) {
+	for _, sess := range testSession {
+		if w, ok := sess.Dialect.(WindowDialect); !ok || !w.SupportsWindow() {
+			continue
+		}
+		prefix := fmt.Sprintf("page_%d_", nextID())
+		b := sess.InsertInto("dbr_keys").Columns("key_value", "val_value")
+		for i := 1; i <= 5; i++ {
+			b.Values(fmt.Sprintf("%s%d", prefix, i), fmt.Sprint(i))
+		}
+		_, err := b.Exec()
+		assert.NoError(t, err)
+
+		query := sess.Select("key_value", "val_value").From("dbr_keys").
+			Where(Like("key_value", EscapeLike(prefix)+"%")).OrderAsc("key_value")
+		var rows []loadByKeysKey
+		page, err := query.Paginate(2, 2).LoadPage(&rows)
+		assert.NoError(t, err)
+		assert.Equal(t, Page{Count: 2, Total: 5}, page)
+		assert.Equal(t, []loadByKeysKey{
+			{KeyValue: prefix + "3", ValValue: "3"},
+			{KeyValue: prefix + "4", ValValue: "4"},
+		}, rows)
+
+		// past the last row
+		rows = nil
+		page, err = query.Paginate(4, 2).LoadPage(&rows)
+		assert.NoError(t, err)
+		assert.Equal(t, Page{Count: 0, Total: 5}, page)
+		assert.Empty(t, rows)
+	}
+}
+
+func TestLoadPageQuery(t *testing.T) {
+	db, dbmock, err := sqlmock.New()
+	assert.NoError(t, err)
+	conn := Connection{DB: db, Dialect: dialect.PostgreSQL, EventReceiver: nullReceiver}
+	sess := conn.NewSession(nil)
+
+	dbmock.ExpectQuery(regexp.QuoteMeta(`SELECT id, name, COUNT(*) OVER () AS "dbr_total" FROM users WHERE ("active" = TRUE) ORDER BY id LIMIT 2 OFFSET 2`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "dbr_total"}).AddRow(3, "c", 7).AddRow(4, "d", 7))
+	var rows []returningRecord
+	page, err := sess.Select("id", "name").From("users").Where(Eq("active", true)).
+		OrderBy("id").Paginate(2, 2).LoadPage(&rows)
+	assert.NoError(t, err)
+	assert.Equal(t, Page{Count: 2, Total: 7}, page)
+	assert.Equal(t, []returningRecord{{ID: 3, Name: "c"}, {ID: 4, Name: "d"}}, rows)
+	assert.NoError(t, dbmock.ExpectationsWereMet())
+
+	// PostgreSQL does not allow FOR UPDATE with window functions
+	dbmock.ExpectQuery(regexp.QuoteMeta(`SELECT id, name FROM users ORDER BY id LIMIT 2 OFFSET 2 FOR UPDATE`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(3, "c"))
+	dbmock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) FROM users`)).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	rows = nil
+	page, err = sess.Select("id", "name").From("users").OrderBy("id").Paginate(2, 2).ForUpdate().LoadPage(&rows)
+	assert.NoError(t, err)
+	assert.Equal(t, Page{Count: 1, Total: 3}, page)
+	assert.NoError(t, dbmock.ExpectationsWereMet())
+
+	// MySQL counts with a second query
+	sess, dbmock = newSessionMock()
+	dbmock.ExpectQuery(regexp.QuoteMeta("SELECT id, name FROM users ORDER BY id LIMIT 2,2")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(3, "c"))
+	dbmock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM users")).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	rows = nil
+	page, err = sess.Select("id", "name").From("users").OrderBy("id").Paginate(2, 2).LoadPage(&rows)
+	assert.NoError(t, err)
+	assert.Equal(t, Page{Count: 1, Total: 3}, page)
+	assert.NoError(t, dbmock.ExpectationsWereMet())
+
+	_, err = sess.SelectBySql("SELECT * FROM users").LoadPage(&rows)
+	assert.Equal(t, ErrNotSupported, err)
+	var row returningRecord
+	_, err = sess.Select("id").From("users").LoadPage(&row)
+	assert.Equal(t, ErrInvalidPointer, err)
+}
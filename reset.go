@@ -0,0 +1,49 @@
+package dbr
+
+// Reset deletes all rows from tables regardless of foreign keys between them,
+// e.g. to clean up after tests. It runs in a single transaction,
+// and settings changed to skip foreign key checks are restored even if a later statement fails.
+// On PostgreSQL tables referencing tables are truncated as well (TRUNCATE ... CASCADE).
+func (sess *Session) Reset(table ...string) error {
+	if len(table) == 0 {
+		return nil
+	}
+	r, ok := baseDialect(sess.Dialect).(ResetDialect)
+	if !ok {
+		return ErrNotSupported
+	}
+	query, restore := r.ResetTables(table)
+	if len(query) == 0 {
+		return ErrNotSupported
+	}
+
+	tx, err := sess.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.RollbackUnlessCommitted()
+
+	// the transaction pins its connection, so the settings saved by the first query
+	// are restored on the same connection
+	saved := false
+	for i, q := range query {
+		_, err = exec(tx, tx.EventReceiver, Expr(q), tx.Dialect)
+		if err != nil {
+			break
+		}
+		saved = saved || i == 0
+	}
+	if !saved {
+		restore = nil
+	}
+	for _, q := range restore {
+		_, restoreErr := exec(tx, tx.EventReceiver, Expr(q), tx.Dialect)
+		if err == nil {
+			err = restoreErr
+		}
+	}
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,65 @@
+package dbr
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lianchengwu/dbr/dialect"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReset(t *testing.T) {
+	for _, sess := range testSession {
+		if sess.Dialect == dialect.ClickHouse {
+			// clickhouse does not support foreign keys
+			continue
+		}
+		for _, v := range []string{
+			`DROP TABLE IF EXISTS dbr_reset_child`,
+			`DROP TABLE IF EXISTS dbr_reset_parent`,
+			`CREATE TABLE dbr_reset_parent (id INTEGER PRIMARY KEY)`,
+			`CREATE TABLE dbr_reset_child (id INTEGER PRIMARY KEY, parent_id INTEGER NOT NULL REFERENCES dbr_reset_parent(id))`,
+			`INSERT INTO dbr_reset_parent (id) VALUES (1)`,
+			`INSERT INTO dbr_reset_child (id, parent_id) VALUES (1, 1)`,
+		} {
+			_, err := sess.Exec(v)
+			assert.NoError(t, err)
+		}
+
+		// parent before child
+		err := sess.Reset("dbr_reset_parent", "dbr_reset_child")
+		assert.NoError(t, err)
+
+		for _, table := range []string{"dbr_reset_parent", "dbr_reset_child"} {
+			count, err := sess.Select("id").From(table).Count()
+			assert.NoError(t, err)
+			assert.EqualValues(t, 0, count)
+		}
+	}
+}
+
+func TestResetRestore(t *testing.T) {
+	sess, mock := newSessionMock()
+	mock.ExpectBegin()
+	mock.ExpectExec("SET @dbr_foreign_key_checks = @@FOREIGN_KEY_CHECKS, FOREIGN_KEY_CHECKS = 0").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("DELETE FROM `a`").WillReturnError(errors.New("lock wait timeout"))
+	mock.ExpectExec("SET FOREIGN_KEY_CHECKS = @dbr_foreign_key_checks").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	err := sess.Reset("a", "b")
+	assert.EqualError(t, err, "lock wait timeout")
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	// nothing is restored if the settings were not saved
+	mock.ExpectBegin()
+	mock.ExpectExec("SET @dbr_foreign_key_checks = @@FOREIGN_KEY_CHECKS, FOREIGN_KEY_CHECKS = 0").WillReturnError(errors.New("access denied"))
+	mock.ExpectRollback()
+
+	err = sess.Reset("a")
+	assert.EqualError(t, err, "access denied")
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	oracle := &Connection{Dialect: dialect.Oracle, EventReceiver: nullReceiver}
+	assert.Equal(t, ErrNotSupported, oracle.NewSession(nil).Reset("a"))
+}
@@ -0,0 +1,63 @@
+package dbr
+
+// Savepoint is a savepoint in a transaction, see Tx.Savepoint
+type Savepoint struct {
+	tx       *Tx
+	rollback string
+	release  string
+}
+
+// Savepoint creates a savepoint named name in tx, `SAVEPOINT name`.
+// The name must consist of letters, digits and underscores, and must not start with a digit,
+// otherwise ErrInvalidSavepoint is returned. A savepoint with an existing name replaces it.
+// It returns ErrNotSupported if the dialect does not support savepoints.
+func (tx *Tx) Savepoint(name string) (*Savepoint, error) {
+	if !validSavepoint(name) {
+		return nil, ErrInvalidSavepoint
+	}
+	s, ok := baseDialect(tx.Dialect).(SavepointDialect)
+	if !ok {
+		return nil, ErrNotSupported
+	}
+	savepoint, rollback, release := s.Savepoint(name)
+	if savepoint == "" {
+		return nil, ErrNotSupported
+	}
+	_, err := exec(tx, tx.EventReceiver, Expr(savepoint), tx.Dialect)
+	if err != nil {
+		return nil, err
+	}
+	return &Savepoint{tx: tx, rollback: rollback, release: release}, nil
+}
+
+// Rollback undoes changes of the transaction made after the savepoint,
+// `ROLLBACK TO SAVEPOINT name`. The savepoint remains, so it can be rolled back again.
+func (sp *Savepoint) Rollback() error {
+	_, err := exec(sp.tx, sp.tx.EventReceiver, Expr(sp.rollback), sp.tx.Dialect)
+	return err
+}
+
+// Release removes the savepoint keeping the changes, `RELEASE SAVEPOINT name`.
+// It does nothing in dialects without RELEASE (Oracle).
+func (sp *Savepoint) Release() error {
+	if sp.release == "" {
+		return nil
+	}
+	_, err := exec(sp.tx, sp.tx.EventReceiver, Expr(sp.release), sp.tx.Dialect)
+	return err
+}
+
+func validSavepoint(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i, c := range name {
+		switch {
+		case c == '_', 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z':
+		case i > 0 && '0' <= c && c <= '9':
+		default:
+			return false
+		}
+	}
+	return true
+}
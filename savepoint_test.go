@@ -0,0 +1,73 @@
+package dbr
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lianchengwu/dbr/dialect"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSavepoint(t *testing.T) {
+	for _, sess := range testSession {
+		if sess.Dialect == dialect.ClickHouse {
+			continue
+		}
+		key := fmt.Sprintf("savepoint_%d", nextID())
+
+		tx, err := sess.Begin()
+		assert.NoError(t, err)
+		_, err = tx.InsertInto("dbr_keys").Columns("key_value", "val_value").Values(key+"_1", "kept").Exec()
+		assert.NoError(t, err)
+
+		sp, err := tx.Savepoint("sp1")
+		assert.NoError(t, err)
+		_, err = tx.InsertInto("dbr_keys").Columns("key_value", "val_value").Values(key+"_2", "undone").Exec()
+		assert.NoError(t, err)
+		assert.NoError(t, sp.Rollback())
+
+		_, err = tx.InsertInto("dbr_keys").Columns("key_value", "val_value").Values(key+"_3", "kept").Exec()
+		assert.NoError(t, err)
+		assert.NoError(t, sp.Release())
+		assert.NoError(t, tx.Commit())
+
+		var keys []string
+		_, err = sess.Select("key_value").From("dbr_keys").
+			Where(Eq("key_value", []string{key + "_1", key + "_2", key + "_3"})).
+			OrderAsc("key_value").
+			Load(&keys)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{key + "_1", key + "_3"}, keys)
+	}
+}
+
+func TestSavepointStmt(t *testing.T) {
+	sess, dbmock := newSessionMock()
+	dbmock.ExpectBegin()
+	dbmock.ExpectExec("SAVEPOINT `before_import`").WillReturnResult(sqlmock.NewResult(0, 0))
+	dbmock.ExpectExec("ROLLBACK TO SAVEPOINT `before_import`").WillReturnResult(sqlmock.NewResult(0, 0))
+	dbmock.ExpectExec("RELEASE SAVEPOINT `before_import`").WillReturnResult(sqlmock.NewResult(0, 0))
+	dbmock.ExpectRollback()
+
+	tx, err := sess.Begin()
+	assert.NoError(t, err)
+	for _, name := range []string{"", "1a", "a-b", "a`b", "a.b", "a b"} {
+		_, err = tx.Savepoint(name)
+		assert.Equal(t, ErrInvalidSavepoint, err, name)
+	}
+	sp, err := tx.Savepoint("before_import")
+	assert.NoError(t, err)
+	assert.NoError(t, sp.Rollback())
+	assert.NoError(t, sp.Release())
+	assert.NoError(t, tx.Rollback())
+	assert.NoError(t, dbmock.ExpectationsWereMet())
+
+	_, rollback, release := dialect.Oracle.Savepoint("a")
+	assert.Equal(t, `ROLLBACK TO SAVEPOINT "a"`, rollback)
+	assert.Equal(t, "", release)
+
+	tx = &Tx{Dialect: dialect.ClickHouse}
+	_, err = tx.Savepoint("a")
+	assert.Equal(t, ErrNotSupported, err)
+}
@@ -1,25 +1,41 @@
 package dbr
 
+import "strings"
+
 // SelectStmt builds `SELECT ...`
 type SelectStmt interface {
 	Builder
 
 	From(table interface{}) SelectStmt
+	Columns(column ...interface{}) SelectStmt
 	Distinct() SelectStmt
+	With(name string, query Builder) SelectStmt
+	WithRecursive(name string, query Builder) SelectStmt
 	Prewhere(query interface{}, value ...interface{}) SelectStmt
 	Where(query interface{}, value ...interface{}) SelectStmt
+	WhereStruct(filter interface{}) SelectStmt
+	WhereStructIn(filter interface{}) SelectStmt
 	Having(query interface{}, value ...interface{}) SelectStmt
 	GroupBy(col ...string) SelectStmt
 	OrderAsc(col string) SelectStmt
 	OrderDesc(col string) SelectStmt
+	OrderBy(col interface{}) SelectStmt
+	OrderByRandom() SelectStmt
+	FromShard(table string, key interface{}) SelectStmt
 	Limit(n uint64) SelectStmt
 	Offset(n uint64) SelectStmt
 	ForUpdate() SelectStmt
 	SkipLocked() SelectStmt
+	Consistency(level string) SelectStmt
 	Join(table, on interface{}) SelectStmt
 	LeftJoin(table, on interface{}) SelectStmt
 	RightJoin(table, on interface{}) SelectStmt
 	FullJoin(table, on interface{}) SelectStmt
+	UseIndex(index ...string) SelectStmt
+	ForceIndex(index ...string) SelectStmt
+	IgnoreIndex(index ...string) SelectStmt
+	StrictIndexHint() SelectStmt
+	TableSample(method string, percent float64) SelectStmt
 	AddComment(text string) SelectStmt
 	As(alias string) Builder
 }
@@ -27,12 +43,20 @@ type SelectStmt interface {
 type selectStmt struct {
 	raw
 
+	CTE         []cte
+	IsRecursive bool
+
 	IsDistinct bool
 
 	Column    []interface{}
+	Into      string
 	Table     interface{}
 	JoinTable []Builder
 
+	IndexHint         []indexHint
+	IsStrictIndexHint bool
+	Sample            *tableSample
+
 	Comment      []Builder
 	PrewhereCond []Builder
 	WhereCond    []Builder
@@ -44,6 +68,70 @@ type selectStmt struct {
 	OffsetCount  int64
 	IsForUpdate  bool
 	IsSkipLocked bool
+
+	ConsistencyLevel string
+}
+
+// read consistency levels for SelectStmt.Consistency
+const (
+	ConsistencyStrong   = "strong"
+	ConsistencyEventual = "eventual"
+)
+
+// cte is a common table expression of WITH
+type cte struct {
+	Name  string
+	Query Builder
+}
+
+type indexHint struct {
+	Hint  string
+	Index []string
+}
+
+// table sample methods for SelectStmt.TableSample
+const (
+	TableSampleSystem    = "SYSTEM"
+	TableSampleBernoulli = "BERNOULLI"
+)
+
+type tableSample struct {
+	Method  string
+	Percent float64
+}
+
+// plainColumns returns whether every column is a column name, e.g. id, users.id, * or users.*
+func plainColumns(column []interface{}) bool {
+	for _, col := range column {
+		s, ok := col.(string)
+		if !ok || s == "" {
+			return false
+		}
+		for _, c := range s {
+			if !(c == '_' || c == '.' || c == '*' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9') {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// isQualifiedStar returns whether column is all columns of a table, e.g. users.* or public.users.*
+func isQualifiedStar(column string) bool {
+	if !strings.HasSuffix(column, ".*") {
+		return false
+	}
+	for _, part := range strings.Split(column[:len(column)-2], ".") {
+		if part == "" {
+			return false
+		}
+		for i, c := range part {
+			if !(c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || i > 0 && c >= '0' && c <= '9') {
+				return false
+			}
+		}
+	}
+	return true
 }
 
 // Build builds `SELECT ...` in dialect
@@ -67,6 +155,26 @@ func (b *selectStmt) Build(d Dialect, buf Buffer) error {
 		}
 	}
 
+	if len(b.CTE) > 0 {
+		buf.WriteString("WITH ")
+		if b.IsRecursive {
+			buf.WriteString("RECURSIVE ")
+		}
+		for i, cte := range b.CTE {
+			if i > 0 {
+				buf.WriteString(", ")
+			}
+			buf.WriteString(d.QuoteIdent(cte.Name))
+			buf.WriteString(" AS (")
+			err := cte.Query.Build(d, buf)
+			if err != nil {
+				return err
+			}
+			buf.WriteString(")")
+		}
+		buf.WriteString(" ")
+	}
+
 	buf.WriteString("SELECT ")
 
 	if b.IsDistinct {
@@ -79,13 +187,22 @@ func (b *selectStmt) Build(d Dialect, buf Buffer) error {
 		}
 		switch col := col.(type) {
 		case string:
-			buf.WriteString(col)
+			if isQualifiedStar(col) {
+				buf.WriteString(d.QuoteIdent(col))
+			} else {
+				buf.WriteString(col)
+			}
 		default:
 			buf.WriteString(placeholder)
 			buf.WriteValue(col)
 		}
 	}
 
+	if b.Into != "" {
+		buf.WriteString(" ")
+		buf.WriteString(b.Into)
+	}
+
 	if b.Table != nil {
 		buf.WriteString(" FROM ")
 		switch table := b.Table.(type) {
@@ -95,6 +212,36 @@ func (b *selectStmt) Build(d Dialect, buf Buffer) error {
 			buf.WriteString(placeholder)
 			buf.WriteValue(table)
 		}
+		if b.Sample != nil {
+			if b.Sample.Method != TableSampleSystem && b.Sample.Method != TableSampleBernoulli ||
+				!(b.Sample.Percent > 0 && b.Sample.Percent <= 100) {
+				return ErrInvalidTableSample
+			}
+			var s string
+			if t, ok := baseDialect(d).(TableSampleDialect); ok {
+				s = t.TableSample(b.Sample.Method, b.Sample.Percent)
+			}
+			if len(s) == 0 {
+				return ErrNotSupported
+			}
+			buf.WriteString(" ")
+			buf.WriteString(s)
+		}
+		h, hasHint := baseDialect(d).(IndexHintDialect)
+		for _, hint := range b.IndexHint {
+			var s string
+			if hasHint {
+				s = h.IndexHint(hint.Hint, hint.Index)
+			}
+			if len(s) == 0 {
+				if b.IsStrictIndexHint {
+					return ErrIndexHintNotSupported
+				}
+				continue
+			}
+			buf.WriteString(" ")
+			buf.WriteString(s)
+		}
 		if len(b.JoinTable) > 0 {
 			for _, join := range b.JoinTable {
 				err := join.Build(d, buf)
@@ -151,10 +298,20 @@ func (b *selectStmt) Build(d Dialect, buf Buffer) error {
 
 	if len(b.Order) > 0 {
 		buf.WriteString(" ORDER BY ")
+		aliased := make(map[string]bool)
+		for _, col := range b.Column {
+			if a, ok := col.(*alias); ok {
+				aliased[a.name] = true
+			}
+		}
 		for i, order := range b.Order {
 			if i > 0 {
 				buf.WriteString(", ")
 			}
+			if o, ok := order.(*orderColumn); ok && aliased[o.column] {
+				o.buildAlias(d, buf)
+				continue
+			}
 			err := order.Build(d, buf)
 			if err != nil {
 				return err
@@ -167,17 +324,69 @@ func (b *selectStmt) Build(d Dialect, buf Buffer) error {
 		buf.WriteString(d.Limit(b.OffsetCount, b.LimitCount))
 	}
 
-	if b.IsForUpdate {
-		buf.WriteString(" FOR UPDATE")
+	lock := "FOR UPDATE"
+	if f, ok := baseDialect(d).(ForUpdateDialect); ok {
+		lock = f.ForUpdate()
+	}
+	if b.IsForUpdate && lock != "" {
+		buf.WriteString(" ")
+		buf.WriteString(lock)
 	}
 
 	if b.IsSkipLocked {
+		if lock == "" {
+			// skipping rows locked by other transactions can not be emulated
+			return ErrNotSupported
+		}
 		buf.WriteString(" SKIP LOCKED")
 	}
 
+	if c, ok := baseDialect(d).(ConsistencyDialect); ok && b.ConsistencyLevel != "" {
+		if s := c.Consistency(b.ConsistencyLevel); s != "" {
+			buf.WriteString(" ")
+			buf.WriteString(s)
+		}
+	}
+
 	return nil
 }
 
+// countStmt returns a statement counting rows of b without ORDER BY, LIMIT, OFFSET and locking.
+// Statements with DISTINCT, GROUP BY, HAVING or columns other than plain column names
+// (aggregates, expressions, subqueries) are counted in a subquery,
+// so the number of groups is returned for GROUP BY and 1 for a single aggregate.
+func (b *selectStmt) countStmt() *selectStmt {
+	stmt := *b
+	if stmt.raw.Query == "" {
+		stmt.Order = nil
+		stmt.LimitCount = -1
+		stmt.OffsetCount = -1
+		stmt.IsForUpdate = false
+		stmt.IsSkipLocked = false
+		if !stmt.IsDistinct && len(stmt.Group) == 0 && len(stmt.HavingCond) == 0 && plainColumns(stmt.Column) {
+			stmt.Column = []interface{}{"COUNT(*)"}
+			return &stmt
+		}
+	}
+	count := createSelectStmt([]interface{}{"COUNT(*)"})
+	count.ConsistencyLevel, stmt.ConsistencyLevel = stmt.ConsistencyLevel, ""
+	count.Table = stmt.As("t")
+	return count
+}
+
+// existsStmt returns a statement selecting whether b has rows,
+// b is limited to one row without ORDER BY and locking
+func (b *selectStmt) existsStmt() *selectStmt {
+	stmt := *b
+	if stmt.raw.Query == "" {
+		stmt.Order = nil
+		stmt.LimitCount = 1
+		stmt.IsForUpdate = false
+		stmt.IsSkipLocked = false
+	}
+	return createSelectStmt([]interface{}{Exists(&stmt)})
+}
+
 // Select creates a SelectStmt
 func Select(column ...interface{}) SelectStmt {
 	return createSelectStmt(column)
@@ -197,6 +406,18 @@ func (b *selectStmt) From(table interface{}) SelectStmt {
 	return b
 }
 
+// FromShard specifies the physical table of a sharded table for key aliased as table, see Session.TableResolver
+func (b *selectStmt) FromShard(table string, key interface{}) SelectStmt {
+	b.Table = Shard(table, key)
+	return b
+}
+
+// Columns adds columns to select, e.g. As("users.id", "user_id")
+func (b *selectStmt) Columns(column ...interface{}) SelectStmt {
+	b.Column = append(b.Column, column...)
+	return b
+}
+
 // SelectBySql creates a SelectStmt from raw query
 func SelectBySql(query string, value ...interface{}) SelectStmt {
 	return createSelectStmtBySQL(query, value)
@@ -219,6 +440,19 @@ func (b *selectStmt) Distinct() SelectStmt {
 	return b
 }
 
+// With adds a common table expression `WITH name AS (query)`
+func (b *selectStmt) With(name string, query Builder) SelectStmt {
+	b.CTE = append(b.CTE, cte{Name: name, Query: query})
+	return b
+}
+
+// WithRecursive adds a common table expression and makes WITH recursive,
+// query is usually a Union or a BuildFunc joining queries with UNION ALL
+func (b *selectStmt) WithRecursive(name string, query Builder) SelectStmt {
+	b.IsRecursive = true
+	return b.With(name, query)
+}
+
 // Prewhere adds a prewhere condition
 // For example clickhouse PREWHERE:
 // https://clickhouse.yandex/docs/en/query_language/select/#prewhere-clause
@@ -243,6 +477,18 @@ func (b *selectStmt) Where(query interface{}, value ...interface{}) SelectStmt {
 	return b
 }
 
+// WhereStruct adds conditions for non-zero fields of filter, see StructCond
+func (b *selectStmt) WhereStruct(filter interface{}) SelectStmt {
+	b.WhereCond = append(b.WhereCond, structCond(filter, false)...)
+	return b
+}
+
+// WhereStructIn adds conditions for non-zero fields of filter, see StructCondIn
+func (b *selectStmt) WhereStructIn(filter interface{}) SelectStmt {
+	b.WhereCond = append(b.WhereCond, structCond(filter, true)...)
+	return b
+}
+
 // Having adds a having condition
 func (b *selectStmt) Having(query interface{}, value ...interface{}) SelectStmt {
 	switch query := query.(type) {
@@ -274,6 +520,37 @@ func (b *selectStmt) OrderDesc(col string) SelectStmt {
 	return b
 }
 
+// OrderBy specifies raw column or Builder for ordering, Build returns ErrInvalidOrder for other values.
+// Columns of OrderBy, OrderAsc and OrderDesc which are aliases of selected columns (see As)
+// are quoted as references to the output column.
+func (b *selectStmt) OrderBy(col interface{}) SelectStmt {
+	switch col := col.(type) {
+	case string:
+		b.Order = append(b.Order, &orderColumn{column: col})
+	case Builder:
+		b.Order = append(b.Order, col)
+	default:
+		b.Order = append(b.Order, BuildFunc(func(Dialect, Buffer) error {
+			return ErrInvalidOrder
+		}))
+	}
+	return b
+}
+
+// OrderByRandom orders rows randomly with the random function of the dialect,
+// e.g. RANDOM() in PostgreSQL and SQLite or RAND() in MySQL. Use it with Limit to fetch random rows.
+func (b *selectStmt) OrderByRandom() SelectStmt {
+	b.Order = append(b.Order, BuildFunc(func(d Dialect, buf Buffer) error {
+		r, ok := baseDialect(d).(RandomDialect)
+		if !ok {
+			return ErrNotSupported
+		}
+		_, err := buf.WriteString(r.Random())
+		return err
+	}))
+	return b
+}
+
 // Limit adds LIMIT
 func (b *selectStmt) Limit(n uint64) SelectStmt {
 	b.LimitCount = int64(n)
@@ -286,18 +563,26 @@ func (b *selectStmt) Offset(n uint64) SelectStmt {
 	return b
 }
 
-// ForUpdate adds `FOR UPDATE`
+// ForUpdate adds `FOR UPDATE`, it is ignored in SQLite which locks the database in transactions
 func (b *selectStmt) ForUpdate() SelectStmt {
 	b.IsForUpdate = true
 	return b
 }
 
-// SkipLocked adds `SKIP LOCKED`
+// SkipLocked adds `SKIP LOCKED`, Build returns ErrNotSupported in SQLite which has no row locks
 func (b *selectStmt) SkipLocked() SelectStmt {
 	b.IsSkipLocked = true
 	return b
 }
 
+// Consistency sets the read consistency level, ConsistencyStrong or ConsistencyEventual,
+// e.g. `SETTINGS select_sequential_consistency = 1` in ClickHouse.
+// It is ignored in dialects without consistency levels.
+func (b *selectStmt) Consistency(level string) SelectStmt {
+	b.ConsistencyLevel = level
+	return b
+}
+
 // Join joins table on condition
 func (b *selectStmt) Join(table, on interface{}) SelectStmt {
 	b.JoinTable = append(b.JoinTable, join(inner, table, on))
@@ -322,6 +607,42 @@ func (b *selectStmt) FullJoin(table, on interface{}) SelectStmt {
 	return b
 }
 
+// UseIndex adds `USE INDEX (...)` after the table.
+// Index hints are skipped in dialects without them, see StrictIndexHint.
+func (b *selectStmt) UseIndex(index ...string) SelectStmt {
+	b.IndexHint = append(b.IndexHint, indexHint{Hint: "USE", Index: index})
+	return b
+}
+
+// ForceIndex adds `FORCE INDEX (...)` after the table
+func (b *selectStmt) ForceIndex(index ...string) SelectStmt {
+	b.IndexHint = append(b.IndexHint, indexHint{Hint: "FORCE", Index: index})
+	return b
+}
+
+// IgnoreIndex adds `IGNORE INDEX (...)` after the table
+func (b *selectStmt) IgnoreIndex(index ...string) SelectStmt {
+	b.IndexHint = append(b.IndexHint, indexHint{Hint: "IGNORE", Index: index})
+	return b
+}
+
+// TableSample adds `TABLESAMPLE method (percent)` after the table to read a random sample of its rows,
+// e.g. for approximate queries. method is TableSampleSystem (pages) or TableSampleBernoulli (rows),
+// percent must be in (0, 100], otherwise Build returns ErrInvalidTableSample.
+// Oracle uses `SAMPLE [BLOCK]` and ClickHouse `SAMPLE` with a fraction,
+// Build returns ErrNotSupported in other dialects.
+func (b *selectStmt) TableSample(method string, percent float64) SelectStmt {
+	b.Sample = &tableSample{Method: strings.ToUpper(method), Percent: percent}
+	return b
+}
+
+// StrictIndexHint makes Build return ErrIndexHintNotSupported
+// instead of skipping index hints in dialects without them
+func (b *selectStmt) StrictIndexHint() SelectStmt {
+	b.IsStrictIndexHint = true
+	return b
+}
+
 // AddComment adds a comment at the beginning of the query
 func (b *selectStmt) AddComment(comment string) SelectStmt {
 	b.Comment = append(b.Comment, Expr(comment))
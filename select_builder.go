@@ -1,6 +1,7 @@
 package dbr
 
 import (
+	"context"
 	"reflect"
 	"time"
 )
@@ -14,26 +15,46 @@ type SelectBuilder interface {
 
 	As(alias string) Builder
 	Comment(text string) SelectBuilder
+	Consistency(level string) SelectBuilder
+	Count() (int64, error)
+	LoadPage(value interface{}) (Page, error)
 	Distinct() SelectBuilder
+	Exists() (bool, error)
+	Pluck(column string, value interface{}) (int, error)
+	LoadByKeys(keyColumn string, keys, dest interface{}) (int, error)
+	IterateRaw() (*RawIterator, error)
+	Iterate(ctx context.Context) (*Iterator, error)
 	ForUpdate() SelectBuilder
 	From(table interface{}) SelectBuilder
+	Columns(column ...interface{}) SelectBuilder
 	FullJoin(table, on interface{}) SelectBuilder
+	ForceIndex(index ...string) SelectBuilder
 	GroupBy(col ...string) SelectBuilder
 	Having(query interface{}, value ...interface{}) SelectBuilder
+	IgnoreIndex(index ...string) SelectBuilder
 	InTimezone(loc *time.Location) SelectBuilder
 	Join(table, on interface{}) SelectBuilder
 	LeftJoin(table, on interface{}) SelectBuilder
 	Limit(n uint64) SelectBuilder
 	Offset(n uint64) SelectBuilder
 	OrderAsc(col string) SelectBuilder
-	OrderBy(col string) SelectBuilder
+	OrderBy(col interface{}) SelectBuilder
+	OrderByRandom() SelectBuilder
+	FromShard(table string, key interface{}) SelectBuilder
 	OrderDesc(col string) SelectBuilder
 	OrderDir(col string, isAsc bool) SelectBuilder
 	Paginate(page, perPage uint64) SelectBuilder
 	Prewhere(query interface{}, value ...interface{}) SelectBuilder
 	RightJoin(table, on interface{}) SelectBuilder
 	SkipLocked() SelectBuilder
+	StrictIndexHint() SelectBuilder
+	TableSample(method string, percent float64) SelectBuilder
+	UseIndex(index ...string) SelectBuilder
 	Where(query interface{}, value ...interface{}) SelectBuilder
+	WhereStruct(filter interface{}) SelectBuilder
+	WhereStructIn(filter interface{}) SelectBuilder
+	With(name string, query Builder) SelectBuilder
+	WithRecursive(name string, query Builder) SelectBuilder
 }
 
 type selectBuilder struct {
@@ -121,7 +142,7 @@ func (b *selectBuilder) Build(d Dialect, buf Buffer) error {
 	return b.selectStmt.Build(d, buf)
 }
 
-// Load loads any value from query result
+// Load loads any value from query result, rows are appended to a slice, see Load
 func (b *selectBuilder) Load(value interface{}) (int, error) {
 	c, err := query(b.runner, b.EventReceiver, b, b.Dialect, value)
 	if err == nil && b.timezone != nil {
@@ -145,7 +166,7 @@ func (b *selectBuilder) LoadStruct(value interface{}) error {
 	return nil
 }
 
-// LoadStructs loads structures from query result
+// LoadStructs loads structures from query result, rows are appended to the slice, see Load
 func (b *selectBuilder) LoadStructs(value interface{}) (int, error) {
 	c, err := query(b.runner, b.EventReceiver, b, b.Dialect, value)
 	if err == nil && b.timezone != nil {
@@ -169,7 +190,7 @@ func (b *selectBuilder) LoadValue(value interface{}) error {
 	return nil
 }
 
-// LoadValues loads any values from query result
+// LoadValues loads any values from query result, rows are appended to the slice, see Load
 func (b *selectBuilder) LoadValues(value interface{}) (int, error) {
 	c, err := query(b.runner, b.EventReceiver, b, b.Dialect, value)
 	if err == nil && b.timezone != nil {
@@ -178,6 +199,52 @@ func (b *selectBuilder) LoadValues(value interface{}) (int, error) {
 	return c, err
 }
 
+// Scan loads the first row into dest in column order, returns ErrNotFound if there is no result
+func (b *selectBuilder) Scan(dest ...interface{}) error {
+	err := scan(b.runner, b.EventReceiver, b, b.Dialect, dest)
+	if err != nil {
+		return err
+	}
+	if b.timezone != nil {
+		for _, v := range dest {
+			b.changeTimezone(reflect.ValueOf(v))
+		}
+	}
+	return nil
+}
+
+// Count returns the number of rows the query would return ignoring ORDER BY, LIMIT and OFFSET.
+// For GROUP BY the number of groups is returned.
+func (b *selectBuilder) Count() (int64, error) {
+	var count int64
+	// BuildFunc prevents top level statement from being parenthesized as subquery
+	stmt := BuildFunc(b.selectStmt.countStmt().Build)
+	_, err := query(b.runner, b.EventReceiver, stmt, b.Dialect, &count)
+	return count, err
+}
+
+// Exists returns whether the stmt has rows with `SELECT EXISTS (... LIMIT 1)`
+func (b *selectBuilder) Exists() (bool, error) {
+	var exists bool
+	stmt := BuildFunc(b.selectStmt.existsStmt().Build)
+	_, err := query(b.runner, b.EventReceiver, stmt, b.Dialect, &exists)
+	return exists, err
+}
+
+// Pluck selects only column, replacing the selected columns, and loads its values
+// into value like LoadValues, e.g. Pluck("id", &ids). WHERE, ORDER BY and LIMIT are kept.
+// It returns ErrNotSupported for raw queries.
+func (b *selectBuilder) Pluck(column string, value interface{}) (int, error) {
+	if b.selectStmt.raw.Query != "" {
+		return 0, ErrNotSupported
+	}
+	stmt := *b.selectStmt
+	stmt.Column = []interface{}{column}
+	pluck := *b
+	pluck.selectStmt = &stmt
+	return pluck.LoadValues(value)
+}
+
 // Join joins table on condition
 func (b *selectBuilder) Join(table, on interface{}) SelectBuilder {
 	b.selectStmt.Join(table, on)
@@ -214,6 +281,13 @@ func (b *selectBuilder) From(table interface{}) SelectBuilder {
 	return b
 }
 
+// Columns adds columns to select, e.g. Builders like As("users.id", "user_id")
+// which are not accepted by Session.Select
+func (b *selectBuilder) Columns(column ...interface{}) SelectBuilder {
+	b.selectStmt.Columns(column...)
+	return b
+}
+
 // GroupBy specifies columns for grouping
 func (b *selectBuilder) GroupBy(col ...string) SelectBuilder {
 	b.selectStmt.GroupBy(col...)
@@ -255,9 +329,33 @@ func (b *selectBuilder) Paginate(page, perPage uint64) SelectBuilder {
 	return b
 }
 
-// OrderBy specifies column for ordering
-func (b *selectBuilder) OrderBy(col string) SelectBuilder {
-	b.selectStmt.Order = append(b.selectStmt.Order, Expr(col))
+// OrderBy specifies raw column or Builder (e.g. OrderByWhitelist) for ordering
+func (b *selectBuilder) OrderBy(col interface{}) SelectBuilder {
+	b.selectStmt.OrderBy(col)
+	return b
+}
+
+// OrderByRandom orders rows randomly, see SelectStmt.OrderByRandom
+func (b *selectBuilder) OrderByRandom() SelectBuilder {
+	b.selectStmt.OrderByRandom()
+	return b
+}
+
+// FromShard specifies the physical table of a sharded table for key, see Session.TableResolver
+func (b *selectBuilder) FromShard(table string, key interface{}) SelectBuilder {
+	b.selectStmt.FromShard(table, key)
+	return b
+}
+
+// With adds a common table expression `WITH name AS (query)`
+func (b *selectBuilder) With(name string, query Builder) SelectBuilder {
+	b.selectStmt.With(name, query)
+	return b
+}
+
+// WithRecursive adds a common table expression and makes WITH recursive
+func (b *selectBuilder) WithRecursive(name string, query Builder) SelectBuilder {
+	b.selectStmt.WithRecursive(name, query)
 	return b
 }
 
@@ -273,6 +371,18 @@ func (b *selectBuilder) Where(query interface{}, value ...interface{}) SelectBui
 	return b
 }
 
+// WhereStruct adds conditions for non-zero fields of filter, see StructCond
+func (b *selectBuilder) WhereStruct(filter interface{}) SelectBuilder {
+	b.selectStmt.WhereStruct(filter)
+	return b
+}
+
+// WhereStructIn adds conditions for non-zero fields of filter, see StructCondIn
+func (b *selectBuilder) WhereStructIn(filter interface{}) SelectBuilder {
+	b.selectStmt.WhereStructIn(filter)
+	return b
+}
+
 // ForUpdate adds lock via FOR UPDATE
 func (b *selectBuilder) ForUpdate() SelectBuilder {
 	b.selectStmt.ForUpdate()
@@ -285,6 +395,42 @@ func (b *selectBuilder) SkipLocked() SelectBuilder {
 	return b
 }
 
+// UseIndex adds `USE INDEX (...)` after the table
+func (b *selectBuilder) UseIndex(index ...string) SelectBuilder {
+	b.selectStmt.UseIndex(index...)
+	return b
+}
+
+// ForceIndex adds `FORCE INDEX (...)` after the table
+func (b *selectBuilder) ForceIndex(index ...string) SelectBuilder {
+	b.selectStmt.ForceIndex(index...)
+	return b
+}
+
+// IgnoreIndex adds `IGNORE INDEX (...)` after the table
+func (b *selectBuilder) IgnoreIndex(index ...string) SelectBuilder {
+	b.selectStmt.IgnoreIndex(index...)
+	return b
+}
+
+// StrictIndexHint returns ErrIndexHintNotSupported for index hints in dialects without them
+func (b *selectBuilder) StrictIndexHint() SelectBuilder {
+	b.selectStmt.StrictIndexHint()
+	return b
+}
+
+// TableSample reads a random sample of the rows of the table, see SelectStmt.TableSample
+func (b *selectBuilder) TableSample(method string, percent float64) SelectBuilder {
+	b.selectStmt.TableSample(method, percent)
+	return b
+}
+
+// Consistency sets the read consistency level, see SelectStmt.Consistency
+func (b *selectBuilder) Consistency(level string) SelectBuilder {
+	b.selectStmt.Consistency(level)
+	return b
+}
+
 // InTimezone all time.Time fields in the result will be returned with the specified location.
 func (b *selectBuilder) InTimezone(loc *time.Location) SelectBuilder {
 	b.timezone = loc
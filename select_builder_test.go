@@ -1,10 +1,13 @@
 package dbr
 
 import (
+	"fmt"
 	"reflect"
+	"regexp"
 	"testing"
 	"time"
 
+	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -72,3 +75,102 @@ func TestChangeTimezone(t *testing.T) {
 		assert.Equal(t, "America/New_York", tt.InnerTime.Location().String())
 	}
 }
+
+func TestSelectBuilderScan(t *testing.T) {
+	sess, dbmock := newSessionMock()
+	dbmock.ExpectQuery("SELECT a, b, c FROM table").
+		WillReturnRows(sqlmock.NewRows([]string{"a", "b", "c"}).AddRow(1, "two", 3.5))
+	var (
+		a int64
+		b string
+		c float64
+	)
+	err := sess.Select("a", "b", "c").From("table").Scan(&a, &b, &c)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, a)
+	assert.Equal(t, "two", b)
+	assert.Equal(t, 3.5, c)
+
+	dbmock.ExpectQuery("SELECT a, b FROM table").
+		WillReturnRows(sqlmock.NewRows([]string{"a", "b"}).AddRow(1, "two"))
+	err = sess.Select("a", "b").From("table").Scan(&a, &b, &c)
+	assert.Equal(t, ErrDestinationCount, err)
+
+	dbmock.ExpectQuery("SELECT a FROM table").
+		WillReturnRows(sqlmock.NewRows([]string{"a"}))
+	err = sess.Select("a").From("table").Scan(&a)
+	assert.Equal(t, ErrNotFound, err)
+	assert.NoError(t, dbmock.ExpectationsWereMet())
+}
+
+func TestSelectBuilderCount(t *testing.T) {
+	for _, sess := range testSession {
+		reset(sess)
+		email := fmt.Sprintf("count%d@example.com", nextID())
+		for _, name := range []string{"a", "a", "b"} {
+			_, err := sess.InsertInto("dbr_people").Columns("id", "name", "email").Values(nextID(), name, email).Exec()
+			assert.NoError(t, err)
+		}
+
+		count, err := sess.Select("*").From("dbr_people").Where(Eq("email", email)).OrderAsc("id").Limit(1).Count()
+		assert.NoError(t, err)
+		assert.EqualValues(t, 3, count)
+
+		count, err = sess.Select("name").From("dbr_people").Where(Eq("email", email)).GroupBy("name").Count()
+		assert.NoError(t, err)
+		assert.EqualValues(t, 2, count)
+
+		count, err = sess.Select("MAX(id)").From("dbr_people").Where(Eq("email", email)).Count()
+		assert.NoError(t, err)
+		assert.EqualValues(t, 1, count)
+	}
+}
+
+func TestSelectBuilderExists(t *testing.T) {
+	for _, sess := range testSession {
+		key := fmt.Sprintf("exists_%d", nextID())
+		_, err := sess.InsertInto("dbr_keys").Columns("key_value", "val_value").Values(key, "a").Exec()
+		assert.NoError(t, err)
+
+		exists, err := sess.Select("*").From("dbr_keys").Where(Eq("key_value", key)).OrderAsc("val_value").Exists()
+		assert.NoError(t, err)
+		assert.True(t, exists)
+
+		exists, err = sess.Select("*").From("dbr_keys").Where(Eq("key_value", key+"_missing")).Exists()
+		assert.NoError(t, err)
+		assert.False(t, exists)
+	}
+}
+
+func TestSelectBuilderPluck(t *testing.T) {
+	for _, sess := range testSession {
+		key := fmt.Sprintf("pluck_%d", nextID())
+		for _, v := range []string{"c", "a", "b"} {
+			_, err := sess.InsertInto("dbr_keys").Columns("key_value", "val_value").Values(key+v, v).Exec()
+			assert.NoError(t, err)
+		}
+
+		var values []string
+		count, err := sess.Select("*").From("dbr_keys").
+			Where(Eq("key_value", []string{key + "a", key + "b", key + "c"})).
+			OrderDesc("val_value").
+			Limit(2).
+			Pluck("val_value", &values)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, count)
+		assert.Equal(t, []string{"c", "b"}, values)
+	}
+
+	sess, dbmock := newSessionMock()
+	dbmock.ExpectQuery(regexp.QuoteMeta("SELECT id FROM users WHERE (`active` = 1) ORDER BY id ASC LIMIT 2")).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3).AddRow(5))
+	var ids []int64
+	count, err := sess.Select("id", "name").From("users").Where(Eq("active", true)).OrderAsc("id").Limit(2).Pluck("id", &ids)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.Equal(t, []int64{3, 5}, ids)
+	assert.NoError(t, dbmock.ExpectationsWereMet())
+
+	_, err = sess.SelectBySql("SELECT id FROM users").Pluck("id", &ids)
+	assert.Equal(t, ErrNotSupported, err)
+}
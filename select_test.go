@@ -34,6 +34,259 @@ func TestSelectStmt(t *testing.T) {
 	assert.EqualError(t, err, ErrPrewhereNotSupported.Error()) // handle PREWHERE statement error
 }
 
+func TestSelectStmtStandardLimit(t *testing.T) {
+	buf := NewBuffer()
+	builder := Select("a").From("table").Where(Eq("b", 1)).OrderAsc("a").Limit(3).Offset(4)
+	err := builder.Build(dialect.Oracle, buf)
+	assert.NoError(t, err)
+	assert.Equal(t, `SELECT a FROM table WHERE ("b" = ?) ORDER BY a ASC OFFSET 4 ROWS FETCH FIRST 3 ROWS ONLY`, buf.String())
+	assert.Equal(t, []interface{}{1}, buf.Value())
+}
+
+func TestSelectStmtCount(t *testing.T) {
+	for _, test := range []struct {
+		stmt  *selectStmt
+		query string
+		value []interface{}
+	}{
+		{
+			stmt: Select("a", "b").From("table").Join("table2", "table.a = table2.a").
+				Where(Eq("c", 1)).OrderAsc("a").Limit(3).Offset(4).ForUpdate().(*selectStmt),
+			query: "SELECT COUNT(*) FROM table JOIN `table2` ON table.a = table2.a WHERE (`c` = 1)",
+		},
+		{
+			stmt:  Select("a", "COUNT(*)").From("table").Where(Eq("c", 1)).GroupBy("a").OrderDesc("a").Limit(3).(*selectStmt),
+			query: "SELECT COUNT(*) FROM (SELECT a, COUNT(*) FROM table WHERE (`c` = 1) GROUP BY a) AS `t`",
+		},
+		{
+			stmt:  Select("MAX(a)").From("table").Where(Eq("c", 1)).(*selectStmt),
+			query: "SELECT COUNT(*) FROM (SELECT MAX(a) FROM table WHERE (`c` = 1)) AS `t`",
+		},
+		{
+			stmt:  Select("a", Expr("b + ?", 1)).From("table").(*selectStmt),
+			query: "SELECT COUNT(*) FROM (SELECT a, b + 1 FROM table) AS `t`",
+		},
+		{
+			stmt:  Select("a").Distinct().From("table").(*selectStmt),
+			query: "SELECT COUNT(*) FROM (SELECT DISTINCT a FROM table) AS `t`",
+		},
+		{
+			stmt:  SelectBySql("SELECT a FROM table WHERE c = ? LIMIT 10", 1).(*selectStmt),
+			query: "SELECT COUNT(*) FROM (SELECT a FROM table WHERE c = 1 LIMIT 10) AS `t`",
+		},
+	} {
+		buf := NewBuffer()
+		err := test.stmt.countStmt().Build(dialect.MySQL, buf)
+		assert.NoError(t, err)
+		query, err := InterpolateForDialect(buf.String(), buf.Value(), dialect.MySQL)
+		assert.NoError(t, err)
+		assert.Equal(t, test.query, query)
+	}
+}
+
+func TestSelectStmtIndexHint(t *testing.T) {
+	builder := Select("a").From("table").
+		ForceIndex("idx_a", "idx_b").
+		IgnoreIndex("idx_c").
+		Join("table2", "table.a = table2.a").
+		Where(Eq("b", 1))
+
+	buf := NewBuffer()
+	err := builder.Build(dialect.MySQL, buf)
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT a FROM table FORCE INDEX (`idx_a`, `idx_b`) IGNORE INDEX (`idx_c`) JOIN `table2` ON table.a = table2.a WHERE (`b` = ?)", buf.String())
+	assert.Equal(t, []interface{}{1}, buf.Value())
+
+	buf = NewBuffer()
+	err = builder.Build(dialect.PostgreSQL, buf)
+	assert.NoError(t, err)
+	assert.Equal(t, `SELECT a FROM table JOIN "table2" ON table.a = table2.a WHERE ("b" = ?)`, buf.String())
+
+	buf = NewBuffer()
+	err = builder.StrictIndexHint().Build(dialect.PostgreSQL, buf)
+	assert.Equal(t, ErrIndexHintNotSupported, err)
+}
+
+func TestSelectStmtTableSample(t *testing.T) {
+	for _, test := range []struct {
+		dialect Dialect
+		method  string
+		percent float64
+		query   string
+		err     error
+	}{
+		{dialect: dialect.PostgreSQL, method: TableSampleSystem, percent: 1, query: `SELECT a FROM t TABLESAMPLE SYSTEM (1) WHERE ("b" = ?)`},
+		{dialect: dialect.PostgreSQL, method: "bernoulli", percent: 0.5, query: `SELECT a FROM t TABLESAMPLE BERNOULLI (0.5) WHERE ("b" = ?)`},
+		{dialect: dialect.Oracle, method: TableSampleSystem, percent: 10, query: `SELECT a FROM t SAMPLE BLOCK (10) WHERE ("b" = ?)`},
+		{dialect: dialect.ClickHouse, method: TableSampleBernoulli, percent: 10, query: "SELECT a FROM t SAMPLE 0.1 WHERE (`b` = ?)"},
+		{dialect: dialect.MySQL, method: TableSampleSystem, percent: 1, err: ErrNotSupported},
+		{dialect: dialect.SQLite3, method: TableSampleSystem, percent: 1, err: ErrNotSupported},
+		{dialect: dialect.PostgreSQL, method: TableSampleSystem, percent: 0, err: ErrInvalidTableSample},
+		{dialect: dialect.PostgreSQL, method: TableSampleSystem, percent: 101, err: ErrInvalidTableSample},
+		{dialect: dialect.PostgreSQL, method: "RANDOM", percent: 1, err: ErrInvalidTableSample},
+	} {
+		buf := NewBuffer()
+		err := Select("a").From("t").TableSample(test.method, test.percent).Where(Eq("b", 1)).Build(test.dialect, buf)
+		assert.Equal(t, test.err, err)
+		if err == nil {
+			assert.Equal(t, test.query, buf.String())
+		}
+	}
+}
+
+func TestSelectStmtConsistency(t *testing.T) {
+	builder := Select("a").From("table").Where(Eq("b", 1)).Limit(1).Consistency(ConsistencyStrong)
+
+	buf := NewBuffer()
+	err := builder.Build(dialect.ClickHouse, buf)
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT a FROM table WHERE (`b` = ?) LIMIT 1 SETTINGS select_sequential_consistency = 1", buf.String())
+
+	buf = NewBuffer()
+	err = builder.Build(dialect.MySQL, buf)
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT a FROM table WHERE (`b` = ?) LIMIT 1", buf.String())
+}
+
+func TestSelectStmtSkipLocked(t *testing.T) {
+	stmt := Select("*").From("jobs").Limit(1).ForUpdate().SkipLocked()
+	buf := NewBuffer()
+	err := stmt.Build(dialect.PostgreSQL, buf)
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM jobs LIMIT 1 FOR UPDATE SKIP LOCKED", buf.String())
+
+	// SQLite ignores FOR UPDATE, but can not skip locked rows
+	buf = NewBuffer()
+	err = Select("*").From("jobs").ForUpdate().Build(dialect.SQLite3, buf)
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM jobs", buf.String())
+	err = stmt.Build(dialect.SQLite3, NewBuffer())
+	assert.Equal(t, ErrNotSupported, err)
+}
+
+func TestSelectStmtExists(t *testing.T) {
+	stmt := Select("*").From("table").Where(Eq("a", 1)).OrderAsc("b").Limit(10).ForUpdate().(*selectStmt)
+	buf := NewBuffer()
+	err := stmt.existsStmt().Build(dialect.MySQL, buf)
+	assert.NoError(t, err)
+	query, err := InterpolateForDialect(buf.String(), buf.Value(), dialect.MySQL)
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT EXISTS (SELECT * FROM table WHERE (`a` = 1) LIMIT 1)", query)
+}
+
+func TestSelectStmtAs(t *testing.T) {
+	stmt := Select(As("u.id", "user_id"), As(Expr("COUNT(*)"), "orders"), "u.name").
+		From(As("users", "u")).
+		Join(As("orders", "o"), "o.user_id = u.id").
+		GroupBy("u.id")
+	for _, test := range []struct {
+		dialect Dialect
+		query   string
+	}{
+		{
+			dialect: dialect.MySQL,
+			query:   "SELECT `u`.`id` AS `user_id`, COUNT(*) AS `orders`, u.name FROM `users` AS `u` JOIN `orders` AS `o` ON o.user_id = u.id GROUP BY u.id",
+		},
+		{
+			dialect: dialect.PostgreSQL,
+			query:   `SELECT "u"."id" AS "user_id", COUNT(*) AS "orders", u.name FROM "users" AS "u" JOIN "orders" AS "o" ON o.user_id = u.id GROUP BY u.id`,
+		},
+	} {
+		buf := NewBuffer()
+		err := stmt.Build(test.dialect, buf)
+		assert.NoError(t, err)
+		query, err := InterpolateForDialect(buf.String(), buf.Value(), test.dialect)
+		assert.NoError(t, err)
+		assert.Equal(t, test.query, query)
+	}
+
+	sess, fake := newFakeSession(dialect.MySQL)
+	_, err := sess.Select("name").Columns(As("users.id", "user_id")).From(As("users", "u")).ReturnStrings()
+	assert.NoError(t, err)
+	stmts := fake.statements()
+	if assert.Len(t, stmts, 1) {
+		assert.Equal(t, "SELECT name, `users`.`id` AS `user_id` FROM `users` AS `u`", stmts[0].query)
+	}
+}
+
+func TestSelectStmtOrderByRandom(t *testing.T) {
+	for _, test := range []struct {
+		dialect Dialect
+		query   string
+	}{
+		{dialect.MySQL, "SELECT id FROM users ORDER BY RAND() LIMIT 3"},
+		{dialect.PostgreSQL, "SELECT id FROM users ORDER BY RANDOM() LIMIT 3"},
+		{dialect.SQLite3, "SELECT id FROM users ORDER BY RANDOM() LIMIT 3"},
+		{dialect.ClickHouse, "SELECT id FROM users ORDER BY rand() LIMIT 3"},
+	} {
+		buf := NewBuffer()
+		err := Select("id").From("users").OrderByRandom().Limit(3).Build(test.dialect, buf)
+		assert.NoError(t, err)
+		assert.Equal(t, test.query, buf.String())
+	}
+
+	for _, sess := range testSession {
+		var id []int64
+		_, err := sess.Select("id").From("dbr_people").OrderByRandom().Limit(1).Load(&id)
+		assert.NoError(t, err)
+	}
+}
+
+func TestSelectStmtOrderByAlias(t *testing.T) {
+	for _, test := range []struct {
+		stmt    SelectStmt
+		dialect Dialect
+		query   string
+	}{
+		{
+			stmt: Select(As(Expr("COUNT(*)"), "order count"), "user_id").From("orders").
+				GroupBy("user_id").OrderDesc("order count").OrderAsc("user_id"),
+			dialect: dialect.MySQL,
+			query:   "SELECT COUNT(*) AS `order count`, user_id FROM orders GROUP BY user_id ORDER BY `order count` DESC, user_id ASC",
+		},
+		{
+			stmt: Select("id", As(Expr("SUM(amount)"), "total")).From("orders").
+				GroupBy("id").OrderBy("total").OrderBy("id DESC"),
+			dialect: dialect.PostgreSQL,
+			query:   `SELECT id, SUM(amount) AS "total" FROM orders GROUP BY id ORDER BY "total", id DESC`,
+		},
+	} {
+		buf := NewBuffer()
+		err := test.stmt.Build(test.dialect, buf)
+		assert.NoError(t, err)
+		query, err := InterpolateForDialect(buf.String(), buf.Value(), test.dialect)
+		assert.NoError(t, err)
+		assert.Equal(t, test.query, query)
+	}
+}
+
+func TestSelectStmtQualifiedStar(t *testing.T) {
+	for _, test := range []struct {
+		dialect Dialect
+		query   string
+	}{
+		{
+			dialect: dialect.MySQL,
+			query:   "SELECT `users`.*, orders.id, `orders`.`total`, `public`.`tags`.*, COUNT(*) FROM users JOIN `orders` ON orders.user_id = users.id",
+		},
+		{
+			dialect: dialect.PostgreSQL,
+			query:   `SELECT "users".*, orders.id, "orders"."total", "public"."tags".*, COUNT(*) FROM users JOIN "orders" ON orders.user_id = users.id`,
+		},
+	} {
+		buf := NewBuffer()
+		err := Select("users.*", "orders.id", I("orders.total"), I("public.tags.*"), "COUNT(*)").From("users").
+			Join("orders", "orders.user_id = users.id").Build(test.dialect, buf)
+		assert.NoError(t, err)
+		query, err := InterpolateForDialect(buf.String(), buf.Value(), test.dialect)
+		assert.NoError(t, err)
+		assert.Equal(t, test.query, query)
+	}
+	assert.False(t, isQualifiedStar("*"))
+	assert.False(t, isQualifiedStar("a.b*"))
+	assert.False(t, isQualifiedStar("(a).*"))
+}
+
 func BenchmarkSelectSQL(b *testing.B) {
 	buf := NewBuffer()
 	for i := 0; i < b.N; i++ {
@@ -0,0 +1,69 @@
+package dbr
+
+// TableResolver returns the physical table of a logical table for the shard key,
+// e.g. "events_2024_01" for "events" and a time, see Session.TableResolver.
+type TableResolver func(table string, key interface{}) (string, error)
+
+type resolverDialect struct {
+	Dialect
+	resolver TableResolver
+}
+
+func (d resolverDialect) unwrap() Dialect {
+	return d.Dialect
+}
+
+// tableResolver returns the TableResolver of the session building with d
+func tableResolver(d Dialect) TableResolver {
+	for {
+		switch w := d.(type) {
+		case resolverDialect:
+			return w.resolver
+		case dialectWrapper:
+			d = w.unwrap()
+		default:
+			return nil
+		}
+	}
+}
+
+// resolveTable returns the physical table of table for key,
+// it returns ErrTableResolverNotSpecified unless the stmt is built by a session with a TableResolver
+func resolveTable(d Dialect, table string, key interface{}) (string, error) {
+	resolver := tableResolver(d)
+	if resolver == nil {
+		return "", ErrTableResolverNotSpecified
+	}
+	return resolver(table, key)
+}
+
+type shardTable struct {
+	table string
+	key   interface{}
+}
+
+// Shard is the physical table of a sharded table for key, resolved with Session.TableResolver,
+// e.g. Join(Shard("events", key), "events.user_id = users.id"). The physical table is aliased
+// as the logical table, so columns qualified with the logical table are valid. See also SelectStmt.FromShard.
+func Shard(table string, key interface{}) Builder {
+	return &shardTable{table: table, key: key}
+}
+
+func (s *shardTable) Build(d Dialect, buf Buffer) error {
+	table, err := resolveTable(d, s.table, s.key)
+	if err != nil {
+		return err
+	}
+	writeShardTable(d, buf, table, s.table)
+	return nil
+}
+
+// writeShardTable writes the physical table aliased as the logical table unless they are the same.
+// AS is omitted, which is not allowed before table aliases in Oracle.
+func writeShardTable(d Dialect, buf Buffer, physical, logical string) {
+	buf.WriteString(d.QuoteIdent(physical))
+	if physical != logical {
+		buf.WriteString(" ")
+		buf.WriteString(d.QuoteIdent(logical))
+	}
+}
@@ -0,0 +1,78 @@
+package dbr
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/lianchengwu/dbr/dialect"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTableResolver(t *testing.T) {
+	sess, fake := newFakeSession(dialect.MySQL)
+	sess.TableResolver = func(table string, key interface{}) (string, error) {
+		switch key := key.(type) {
+		case time.Time:
+			return fmt.Sprintf("%s_%d_%02d", table, key.Year(), key.Month()), nil
+		case int:
+			return fmt.Sprintf("%s_%d", table, key%4), nil
+		}
+		return "", fmt.Errorf("invalid shard key %v", key)
+	}
+	month := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	var ids []int64
+	_, err := sess.Select("id").FromShard("events", month).Where(Eq("user_id", 1)).Load(&ids)
+	assert.NoError(t, err)
+	_, err = sess.Select("users.id").From("users").
+		Join(Shard("profiles", 5), "profiles.user_id = users.id").
+		Where(Expr("users.id IN ?", Select("user_id").FromShard("events", month))).Load(&ids)
+	assert.NoError(t, err)
+	_, err = sess.InsertInto("events").Shard(month).Columns("user_id").Values(1).Exec()
+	assert.NoError(t, err)
+	_, err = sess.Update("events").Shard(month).Set("user_id", 2).Where(Eq("id", 3)).Exec()
+	assert.NoError(t, err)
+	_, err = sess.DeleteFrom("events").Shard(month).Where(Eq("id", 3)).Exec()
+	assert.NoError(t, err)
+	_, err = sess.Update("events").Shard(month).Join("users", "events.user_id = users.id").
+		Set("user_id", 2).Where(Eq("users.name", "a")).Exec()
+	assert.NoError(t, err)
+
+	var query []string
+	for _, stmt := range fake.statements() {
+		query = append(query, stmt.query)
+	}
+	assert.Equal(t, []string{
+		"SELECT id FROM `events_2024_01` `events` WHERE (`user_id` = 1)",
+		"SELECT users.id FROM users JOIN `profiles_1` `profiles` ON profiles.user_id = users.id " +
+			"WHERE (users.id IN (SELECT user_id FROM `events_2024_01` `events`))",
+		"INSERT INTO `events_2024_01` (`user_id`) VALUES (1)",
+		"UPDATE `events_2024_01` SET `user_id` = 2 WHERE (`id` = 3)",
+		"DELETE FROM `events_2024_01` WHERE (`id` = 3)",
+		"UPDATE `events_2024_01` `events` JOIN `users` ON events.user_id = users.id " +
+			"SET `events`.`user_id` = 2 WHERE (`users`.`name` = 'a')",
+	}, query)
+
+	_, err = sess.Select("id").FromShard("events", "bad").Load(&ids)
+	assert.EqualError(t, err, "invalid shard key bad")
+
+	// sharded tables are resolved by sessions
+	err = Select("id").FromShard("events", month).Build(dialect.MySQL, NewBuffer())
+	assert.NoError(t, err)
+	_, err = InterpolateForDialect("SELECT * FROM ?", []interface{}{Shard("events", month)}, dialect.MySQL)
+	assert.Equal(t, ErrTableResolverNotSpecified, err)
+	err = DeleteFrom("events").Shard(month).Build(dialect.MySQL, NewBuffer())
+	assert.Equal(t, ErrTableResolverNotSpecified, err)
+
+	// the updated table is aliased for the tables of FROM
+	sess, fake = newFakeSession(dialect.PostgreSQL)
+	sess.TableResolver = func(table string, key interface{}) (string, error) {
+		return fmt.Sprintf("%s_%v", table, key), nil
+	}
+	_, err = sess.Update("events").Shard(1).From("users").Set("user_id", I("users.id")).
+		Where(Expr("events.email = users.email")).Exec()
+	assert.NoError(t, err)
+	assert.Equal(t, `UPDATE "events_1" "events" SET "user_id" = "users"."id" FROM "users" WHERE (events.email = users.email)`,
+		fake.statements()[0].query)
+}
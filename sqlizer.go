@@ -0,0 +1,73 @@
+package dbr
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+)
+
+// Sqlizer is implemented by squirrel builders and expressions (e.g. squirrel.Eq),
+// it is declared here to wrap them without the dependency, see FromSqlizer
+type Sqlizer interface {
+	ToSql() (string, []interface{}, error)
+}
+
+// FromSqlizer wraps s as a Builder, e.g. Where(FromSqlizer(squirrel.Like{"name": "a%"})),
+// to migrate from squirrel incrementally. Numbered placeholders (`$1` of squirrel.Dollar)
+// are translated to `?` with the values reordered, so s can use any placeholder format,
+// and the placeholders are rendered by the dialect like in Expr.
+func FromSqlizer(s Sqlizer) Builder {
+	return BuildFunc(func(d Dialect, buf Buffer) error {
+		query, value, err := s.ToSql()
+		if err != nil {
+			return err
+		}
+		query, value, err = unnumberPlaceholders(query, value)
+		if err != nil {
+			return err
+		}
+		return Expr(query, value...).Build(d, buf)
+	})
+}
+
+// unnumberPlaceholders replaces `$N` outside of quoted strings with `?` and value[N-1]
+func unnumberPlaceholders(query string, value []interface{}) (string, []interface{}, error) {
+	if strings.IndexByte(query, '$') < 0 {
+		return query, value, nil
+	}
+	var buf bytes.Buffer
+	var ordered []interface{}
+	var quote byte
+	for i := 0; i < len(query); i++ {
+		c := query[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '$':
+			j := i + 1
+			for j < len(query) && query[j] >= '0' && query[j] <= '9' {
+				j++
+			}
+			if j == i+1 {
+				break
+			}
+			n, err := strconv.Atoi(query[i+1 : j])
+			if err != nil || n < 1 || n > len(value) {
+				return "", nil, ErrPlaceholderCount
+			}
+			buf.WriteString(placeholder)
+			ordered = append(ordered, value[n-1])
+			i = j - 1
+			continue
+		}
+		buf.WriteByte(c)
+	}
+	if len(ordered) == 0 {
+		return query, value, nil
+	}
+	return buf.String(), ordered, nil
+}
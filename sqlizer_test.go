@@ -0,0 +1,71 @@
+package dbr
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/lianchengwu/dbr/dialect"
+	"github.com/stretchr/testify/assert"
+)
+
+// sqlizer stands for a squirrel expression
+type sqlizer struct {
+	query string
+	value []interface{}
+	err   error
+}
+
+func (s sqlizer) ToSql() (string, []interface{}, error) {
+	return s.query, s.value, s.err
+}
+
+func TestFromSqlizer(t *testing.T) {
+	for _, test := range []struct {
+		sqlizer sqlizer
+		query   string
+		value   []interface{}
+	}{
+		{
+			// squirrel.Question
+			sqlizer: sqlizer{query: "b = ? AND c = ?", value: []interface{}{2, 3}},
+			query:   `SELECT * FROM t WHERE ("a" = ?) AND (b = ? AND c = ?) AND ("d" = ?)`,
+			value:   []interface{}{1, 2, 3, 4},
+		},
+		{
+			// squirrel.Dollar, numbers are renumbered in the query
+			sqlizer: sqlizer{query: "b = $2 AND c = $1 AND e = '$1'", value: []interface{}{3, 2}},
+			query:   `SELECT * FROM t WHERE ("a" = ?) AND (b = ? AND c = ? AND e = '$1') AND ("d" = ?)`,
+			value:   []interface{}{1, 2, 3, 4},
+		},
+	} {
+		stmt := Select("*").From("t").
+			Where(Eq("a", 1)).
+			Where(FromSqlizer(test.sqlizer)).
+			Where(Eq("d", 4))
+		buf := NewBuffer()
+		err := stmt.Build(dialect.PostgreSQL, buf)
+		assert.NoError(t, err)
+		assert.Equal(t, test.query, buf.String())
+		assert.Equal(t, test.value, buf.Value())
+	}
+
+	sess, fake := newFakeSession(dialect.PostgreSQL)
+	sess.DisableInterpolation = true
+	_, err := sess.Select("*").From("t").
+		Where(Eq("a", 1)).
+		Where(FromSqlizer(sqlizer{query: "b = $2 AND c = $1", value: []interface{}{3, 2}})).
+		ReturnStrings()
+	assert.NoError(t, err)
+	stmts := fake.statements()
+	if assert.Len(t, stmts, 1) {
+		assert.Equal(t, `SELECT * FROM t WHERE ("a" = $1) AND (b = $2 AND c = $3)`, stmts[0].query)
+		assert.Equal(t, []interface{}{1, 2, 3}, stmts[0].args)
+	}
+
+	buf := NewBuffer()
+	err = FromSqlizer(sqlizer{query: "b = $2", value: []interface{}{1}}).Build(dialect.PostgreSQL, buf)
+	assert.Equal(t, ErrPlaceholderCount, err)
+	errSqlizer := errors.New("sqlizer")
+	err = FromSqlizer(sqlizer{err: errSqlizer}).Build(dialect.PostgreSQL, buf)
+	assert.Equal(t, errSqlizer, err)
+}
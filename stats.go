@@ -0,0 +1,91 @@
+package dbr
+
+import (
+	"strconv"
+	"sync"
+	"time"
+)
+
+// DefaultStatsInterval is the interval of ReportStats if it is not positive
+const DefaultStatsInterval = time.Minute
+
+type statsReporter struct {
+	conn *Connection
+	done chan struct{}
+	once sync.Once
+}
+
+// stop stops reporting and removes the reporter from the connection
+func (r *statsReporter) stop() {
+	r.once.Do(func() {
+		close(r.done)
+		r.conn.reportMu.Lock()
+		defer r.conn.reportMu.Unlock()
+		for i, reporter := range r.conn.reporters {
+			if reporter == r {
+				r.conn.reporters = append(r.conn.reporters[:i], r.conn.reporters[i+1:]...)
+				break
+			}
+		}
+	})
+}
+
+// ReportStats sends the statistics of the connection pool (see sql.DB.Stats) to the EventReceiver
+// of the connection as "dbr.pool.stats" event every interval, e.g. to monitor pool saturation.
+// The event has "max_open_connections", "open_connections", "in_use", "idle", "wait_count",
+// "wait_duration" (in nanoseconds), "max_idle_closed" and "max_lifetime_closed".
+// Reporting stops when stop is called or the connection is closed.
+// If interval is not positive, DefaultStatsInterval is used.
+func (conn *Connection) ReportStats(interval time.Duration) (stop func()) {
+	if interval <= 0 {
+		interval = DefaultStatsInterval
+	}
+	log := conn.EventReceiver
+	if log == nil {
+		log = nullReceiver
+	}
+	r := &statsReporter{conn: conn, done: make(chan struct{})}
+	conn.reportMu.Lock()
+	conn.reporters = append(conn.reporters, r)
+	conn.reportMu.Unlock()
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-r.done:
+				return
+			case <-ticker.C:
+				conn.reportStats(log)
+			}
+		}
+	}()
+	return r.stop
+}
+
+func (conn *Connection) reportStats(log EventReceiver) {
+	stats := conn.DB.Stats()
+	log.EventKv("dbr.pool.stats", kvs{
+		"max_open_connections": strconv.Itoa(stats.MaxOpenConnections),
+		"open_connections":     strconv.Itoa(stats.OpenConnections),
+		"in_use":               strconv.Itoa(stats.InUse),
+		"idle":                 strconv.Itoa(stats.Idle),
+		"wait_count":           strconv.FormatInt(stats.WaitCount, 10),
+		"wait_duration":        strconv.FormatInt(stats.WaitDuration.Nanoseconds(), 10),
+		"max_idle_closed":      strconv.FormatInt(stats.MaxIdleClosed, 10),
+		"max_lifetime_closed":  strconv.FormatInt(stats.MaxLifetimeClosed, 10),
+	})
+}
+
+// Close stops reporting stats (see ReportStats) and closes the database
+func (conn *Connection) Close() error {
+	conn.reportMu.Lock()
+	reporters := conn.reporters
+	conn.reporters = nil
+	conn.reportMu.Unlock()
+	for _, r := range reporters {
+		r.stop()
+	}
+	return conn.DB.Close()
+}
@@ -0,0 +1,71 @@
+package dbr
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/lianchengwu/dbr/dialect"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConnectionReportStats(t *testing.T) {
+	log := &testEventReceiver{}
+	conn, err := Open("sqlite3", ":memory:", log)
+	if !assert.NoError(t, err) {
+		return
+	}
+	conn.SetMaxOpenConns(3)
+	statsEvents := func() int {
+		log.mu.Lock()
+		defer log.mu.Unlock()
+		count := 0
+		for _, e := range log.events {
+			if e.name == "dbr.pool.stats" {
+				count++
+			}
+		}
+		return count
+	}
+
+	stop := conn.ReportStats(time.Millisecond)
+	for i := 0; i < 1000 && statsEvents() < 2; i++ {
+		time.Sleep(time.Millisecond)
+	}
+	e, ok := log.find("dbr.pool.stats")
+	if assert.True(t, ok) {
+		assert.Equal(t, "3", e.kvs["max_open_connections"])
+		assert.Equal(t, "0", e.kvs["in_use"])
+		assert.Contains(t, e.kvs, "wait_duration")
+	}
+	stop()
+	stop()
+	// stopped reporters are not kept until the connection is closed
+	assert.Empty(t, conn.reporters)
+
+	// reporters stop when the connection is closed
+	conn.ReportStats(time.Millisecond)
+	assert.NoError(t, conn.Close())
+	time.Sleep(5 * time.Millisecond)
+	count := statsEvents()
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, count, statsEvents())
+}
+
+func TestConnectionReportStatsDefaults(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	if !assert.NoError(t, err) {
+		return
+	}
+	// a Connection without EventReceiver reports to nullReceiver
+	conn := &Connection{DB: db, Dialect: dialect.SQLite3}
+	stop := conn.ReportStats(time.Millisecond)
+	time.Sleep(5 * time.Millisecond)
+	stop()
+
+	// a non-positive interval does not panic
+	stop = conn.ReportStats(0)
+	stop()
+	conn.ReportStats(-time.Second)
+	assert.NoError(t, conn.Close())
+}
@@ -0,0 +1,69 @@
+package dbr
+
+// TempTable is a temporary table created by Tx.CreateTempTable,
+// it can be used as a table of From and Join, e.g. From(tmp).
+type TempTable struct {
+	Name string
+}
+
+// Build writes the quoted name of the table
+func (t *TempTable) Build(d Dialect, buf Buffer) error {
+	_, err := buf.WriteString(d.QuoteIdent(t.Name))
+	return err
+}
+
+// CreateTempTable creates a temporary table with the column definitions, e.g. "id BIGINT PRIMARY KEY",
+// which is dropped when the transaction is committed or rolled back: with `ON COMMIT DROP` in PostgreSQL,
+// and by dropping it before the end of the transaction in MySQL and SQLite.
+// Column definitions are written as is, so they must not contain user input.
+//
+// Temporary tables are local to a connection of the pool, so they can only be used
+// by the queries of the transaction. In MySQL a table created by a transaction
+// which is rolled back by Session.TxTimeoutRollback is kept until the connection is closed.
+// It returns ErrNotSupported in Oracle (global temporary tables are a part of schema) and ClickHouse.
+func (tx *Tx) CreateTempTable(name string, column ...string) (*TempTable, error) {
+	if name == "" {
+		return nil, ErrTableNotSpecified
+	}
+	if len(column) == 0 {
+		return nil, ErrColumnNotSpecified
+	}
+	t, ok := baseDialect(tx.Dialect).(TempTableDialect)
+	if !ok {
+		return nil, ErrNotSupported
+	}
+	create, drop := t.CreateTempTable(name, column)
+	if create == "" {
+		return nil, ErrNotSupported
+	}
+	_, err := exec(tx, tx.EventReceiver, Expr(create), tx.Dialect)
+	if err != nil {
+		return nil, err
+	}
+	if drop != "" {
+		tx.mu.Lock()
+		tx.tempDrop = append(tx.tempDrop, drop)
+		tx.mu.Unlock()
+	}
+	return &TempTable{Name: name}, nil
+}
+
+// dropTempTables drops the temporary tables of the transaction before it finishes.
+// The lock is held until the tables are dropped, so the rollback of Session.TxTimeoutRollback
+// waits for the drops instead of running them in a finished transaction.
+func (tx *Tx) dropTempTables() error {
+	tx.mu.Lock()
+	defer tx.mu.Unlock()
+	drop := tx.tempDrop
+	if tx.state != txActive {
+		drop = nil
+	}
+	tx.tempDrop = nil
+	for _, query := range drop {
+		_, err := exec(tx, tx.EventReceiver, Expr(query), tx.Dialect)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
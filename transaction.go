@@ -3,6 +3,16 @@ package dbr
 import (
 	"context"
 	"database/sql"
+	"sync"
+	"time"
+)
+
+type txState uint8
+
+const (
+	txActive txState = iota
+	txCommitted
+	txRolledBack
 )
 
 // Tx is a transaction for the given Session
@@ -10,7 +20,15 @@ type Tx struct {
 	EventReceiver
 	Dialect Dialect
 	*sql.Tx
-	ctx context.Context
+	ctx     context.Context
+	session *Session
+
+	started time.Time
+
+	mu       sync.Mutex
+	state    txState
+	timer    *time.Timer
+	tempDrop []string
 }
 
 // Begin creates a transaction for the given session
@@ -26,17 +44,82 @@ func (sess *Session) BeginWithOpts(opts *sql.TxOptions) (*Tx, error) {
 	}
 	sess.Event("dbr.begin")
 
-	return &Tx{
+	t := &Tx{
 		EventReceiver: sess,
 		Dialect:       sess.Dialect,
 		Tx:            tx,
 		ctx:           sess.ctx,
-	}, nil
+		session:       sess,
+		started:       time.Now(),
+	}
+	if sess.TxTimeout > 0 {
+		t.watch(sess.TxTimeout, sess.TxTimeoutRollback)
+	}
+	return t, nil
+}
+
+// watch reports the transaction which is still open after timeout,
+// and rolls it back if rollback is set
+func (tx *Tx) watch(timeout time.Duration, rollback bool) {
+	tx.mu.Lock()
+	defer tx.mu.Unlock()
+	tx.timer = time.AfterFunc(timeout, func() {
+		tx.EventKv("dbr.tx.timeout", eventKvs(tx.ctx, kvs{
+			"elapsed": time.Since(tx.started).String(),
+		}))
+		if !rollback || tx.finish(txRolledBack) != nil {
+			return
+		}
+		err := tx.Tx.Rollback()
+		if err != nil {
+			tx.EventErr("dbr.tx.timeout.rollback", err)
+		} else {
+			tx.Event("dbr.rollback")
+		}
+	})
+}
+
+// finish moves the active transaction to state,
+// it returns ErrTxCommitted or ErrTxRolledBack if the transaction is already finished
+func (tx *Tx) finish(state txState) error {
+	tx.mu.Lock()
+	defer tx.mu.Unlock()
+	switch tx.state {
+	case txCommitted:
+		return ErrTxCommitted
+	case txRolledBack:
+		return ErrTxRolledBack
+	}
+	tx.state = state
+	if tx.timer != nil {
+		tx.timer.Stop()
+	}
+	return nil
+}
+
+func (tx *Tx) getContext() context.Context {
+	return tx.ctx
 }
 
-// Commit finishes the transaction
+func (tx *Tx) getSession() *Session {
+	return tx.session
+}
+
+func (tx *Tx) getTx() *Tx {
+	return tx
+}
+
+// Commit finishes the transaction.
+// It returns ErrTxCommitted or ErrTxRolledBack if the transaction is already finished.
 func (tx *Tx) Commit() error {
-	err := tx.Tx.Commit()
+	err := tx.dropTempTables()
+	if err != nil {
+		return tx.EventErr("dbr.commit.error", err)
+	}
+	err = tx.finish(txCommitted)
+	if err == nil {
+		err = tx.Tx.Commit()
+	}
 	if err != nil {
 		return tx.EventErr("dbr.commit.error", err)
 	}
@@ -44,9 +127,15 @@ func (tx *Tx) Commit() error {
 	return nil
 }
 
-// Rollback cancels the transaction
+// Rollback cancels the transaction.
+// It returns ErrTxCommitted or ErrTxRolledBack if the transaction is already finished.
 func (tx *Tx) Rollback() error {
-	err := tx.Tx.Rollback()
+	// errors are sent to the EventReceiver, the rollback is more important
+	tx.dropTempTables()
+	err := tx.finish(txRolledBack)
+	if err == nil {
+		err = tx.Tx.Rollback()
+	}
 	if err != nil {
 		return tx.EventErr("dbr.rollback", err)
 	}
@@ -54,14 +143,34 @@ func (tx *Tx) Rollback() error {
 	return nil
 }
 
+// SetConstraints defers the checks of deferrable constraints to the commit, `SET CONSTRAINTS ALL DEFERRED`,
+// e.g. to insert rows referencing each other in any order, or makes them immediate again.
+// Only constraints declared DEFERRABLE are affected; it lasts until the end of the transaction.
+// It returns ErrNotSupported in dialects without deferrable constraints (PostgreSQL and Oracle have them).
+func (tx *Tx) SetConstraints(deferred bool) error {
+	c, ok := baseDialect(tx.Dialect).(ConstraintsDialect)
+	if !ok {
+		return ErrNotSupported
+	}
+	query := c.SetConstraints(deferred)
+	if query == "" {
+		return ErrNotSupported
+	}
+	_, err := exec(tx, tx.EventReceiver, Expr(query), tx.Dialect)
+	return err
+}
+
 // RollbackUnlessCommitted rollsback the transaction unless it has already been committed or rolled back.
 // Useful to defer tx.RollbackUnlessCommitted() -- so you don't have to handle N failure cases
 // Keep in mind the only way to detect an error on the rollback is via the event log.
 func (tx *Tx) RollbackUnlessCommitted() {
+	tx.dropTempTables()
+	if tx.finish(txRolledBack) != nil {
+		// already finished
+		return
+	}
 	err := tx.Tx.Rollback()
-	if err == sql.ErrTxDone {
-		// ok
-	} else if err != nil {
+	if err != nil {
 		tx.EventErr("dbr.rollback_unless_committed", err)
 	} else {
 		tx.Event("dbr.rollback")
@@ -1,10 +1,12 @@
 package dbr
 
 import (
-	"github.com/lianchengwu/dbr/dialect"
-
+	"errors"
+	"fmt"
 	"testing"
+	"time"
 
+	"github.com/lianchengwu/dbr/dialect"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -61,3 +63,215 @@ func TestTransactionRollback(t *testing.T) {
 		assert.Error(t, err)
 	}
 }
+
+func TestTransactionTimeout(t *testing.T) {
+	for _, test := range []struct {
+		delay    time.Duration
+		rollback bool
+		timeout  bool
+	}{
+		{delay: 0, timeout: false},
+		{delay: 50 * time.Millisecond, timeout: true},
+		{delay: 50 * time.Millisecond, rollback: true, timeout: true},
+	} {
+		sess, dbmock := newSessionMock()
+		recv := &testEventReceiver{}
+		sess.EventReceiver = recv
+		sess.TxTimeout = 10 * time.Millisecond
+		sess.TxTimeoutRollback = test.rollback
+
+		dbmock.ExpectBegin()
+		if test.rollback {
+			dbmock.ExpectRollback()
+		} else {
+			dbmock.ExpectCommit()
+		}
+
+		tx, err := sess.Begin()
+		assert.NoError(t, err)
+		time.Sleep(test.delay)
+		err = tx.Commit()
+		if test.rollback {
+			assert.Equal(t, ErrTxRolledBack, err)
+		} else {
+			assert.NoError(t, err)
+		}
+
+		_, ok := recv.find("dbr.tx.timeout")
+		assert.Equal(t, test.timeout, ok)
+		assert.NoError(t, dbmock.ExpectationsWereMet())
+	}
+}
+
+func TestTransactionState(t *testing.T) {
+	sess, dbmock := newSessionMock()
+	recv := &testEventReceiver{}
+	sess.EventReceiver = recv
+
+	dbmock.ExpectBegin()
+	dbmock.ExpectCommit()
+	tx, err := sess.Begin()
+	assert.NoError(t, err)
+	assert.NoError(t, tx.Commit())
+	assert.Equal(t, ErrTxCommitted, tx.Commit())
+	assert.Equal(t, ErrTxCommitted, tx.Rollback())
+	tx.RollbackUnlessCommitted()
+	_, ok := recv.find("dbr.rollback_unless_committed")
+	assert.False(t, ok)
+
+	dbmock.ExpectBegin()
+	dbmock.ExpectRollback()
+	tx, err = sess.Begin()
+	assert.NoError(t, err)
+	assert.NoError(t, tx.Rollback())
+	assert.Equal(t, ErrTxRolledBack, tx.Commit())
+	assert.Equal(t, ErrTxRolledBack, tx.Rollback())
+	tx.RollbackUnlessCommitted()
+
+	// rollback error is reported to event receiver
+	rollbackErr := errors.New("connection lost")
+	dbmock.ExpectBegin()
+	dbmock.ExpectRollback().WillReturnError(rollbackErr)
+	tx, err = sess.Begin()
+	assert.NoError(t, err)
+	tx.RollbackUnlessCommitted()
+	e, ok := recv.find("dbr.rollback_unless_committed")
+	if assert.True(t, ok) {
+		assert.Equal(t, rollbackErr, e.err)
+	}
+	assert.Equal(t, ErrTxRolledBack, tx.Commit())
+	assert.NoError(t, dbmock.ExpectationsWereMet())
+}
+
+func TestTransactionSetConstraints(t *testing.T) {
+	for _, sess := range testSession {
+		if sess.Dialect != dialect.PostgreSQL {
+			continue
+		}
+		tx, err := sess.Begin()
+		assert.NoError(t, err)
+		defer tx.RollbackUnlessCommitted()
+
+		// DDL is transactional in PostgreSQL, the tables are dropped by the rollback
+		for _, q := range []string{
+			`CREATE TABLE dbr_deferred_parent (id integer PRIMARY KEY)`,
+			`CREATE TABLE dbr_deferred_child (id integer PRIMARY KEY,
+				parent_id integer REFERENCES dbr_deferred_parent (id) DEFERRABLE INITIALLY IMMEDIATE)`,
+		} {
+			_, err = tx.Exec(q)
+			assert.NoError(t, err)
+		}
+
+		assert.NoError(t, tx.SetConstraints(true))
+		// the child is inserted before its parent
+		_, err = tx.InsertInto("dbr_deferred_child").Pair("id", 1).Pair("parent_id", 10).Exec()
+		assert.NoError(t, err)
+		_, err = tx.InsertInto("dbr_deferred_parent").Pair("id", 10).Exec()
+		assert.NoError(t, err)
+		assert.NoError(t, tx.SetConstraints(false))
+		assert.NoError(t, tx.Rollback())
+	}
+}
+
+func TestTransactionSetConstraintsStatement(t *testing.T) {
+	sess, fake := newFakeSession(dialect.PostgreSQL)
+	tx, err := sess.Begin()
+	assert.NoError(t, err)
+	assert.NoError(t, tx.SetConstraints(true))
+	assert.NoError(t, tx.SetConstraints(false))
+	assert.NoError(t, tx.Commit())
+	stmts := fake.statements()
+	if assert.Len(t, stmts, 2) {
+		assert.Equal(t, "SET CONSTRAINTS ALL DEFERRED", stmts[0].query)
+		assert.Equal(t, "SET CONSTRAINTS ALL IMMEDIATE", stmts[1].query)
+	}
+
+	sess, _ = newFakeSession(dialect.MySQL)
+	tx, err = sess.Begin()
+	assert.NoError(t, err)
+	assert.Equal(t, ErrNotSupported, tx.SetConstraints(true))
+	assert.NoError(t, tx.Rollback())
+}
+
+func TestTransactionTempTable(t *testing.T) {
+	for _, sess := range testSession {
+		if sess.Dialect == dialect.ClickHouse {
+			continue
+		}
+		prefix := fmt.Sprintf("temp_%d_", nextID())
+		_, err := sess.InsertInto("dbr_keys").Columns("key_value", "val_value").
+			Values(prefix+"1", "a").Values(prefix+"2", "b").Exec()
+		assert.NoError(t, err)
+
+		tx, err := sess.Begin()
+		assert.NoError(t, err)
+		tmp, err := tx.CreateTempTable("dbr_temp_keys", "key_value varchar(255) PRIMARY KEY")
+		assert.NoError(t, err)
+		_, err = tx.InsertInto(tmp.Name).Columns("key_value").Values(prefix + "2").Exec()
+		assert.NoError(t, err)
+
+		var values []string
+		_, err = tx.Select("dbr_keys.val_value").From(tmp).
+			Join("dbr_keys", "dbr_keys.key_value = dbr_temp_keys.key_value").Load(&values)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"b"}, values)
+		assert.NoError(t, tx.Commit())
+
+		// the table is dropped, so it can be created again
+		tx, err = sess.Begin()
+		assert.NoError(t, err)
+		_, err = tx.CreateTempTable("dbr_temp_keys", "key_value varchar(255)")
+		assert.NoError(t, err)
+		assert.NoError(t, tx.Rollback())
+	}
+}
+
+func TestTransactionTempTableStatement(t *testing.T) {
+	for _, test := range []struct {
+		dialect Dialect
+		query   []string
+	}{
+		{
+			dialect: dialect.PostgreSQL,
+			query:   []string{`CREATE TEMPORARY TABLE "ids" (id bigint, name text) ON COMMIT DROP`},
+		},
+		{
+			dialect: dialect.MySQL,
+			query: []string{
+				"CREATE TEMPORARY TABLE `ids` (id bigint, name text)",
+				"DROP TEMPORARY TABLE IF EXISTS `ids`",
+			},
+		},
+		{
+			dialect: dialect.SQLite3,
+			query: []string{
+				`CREATE TEMPORARY TABLE "ids" (id bigint, name text)`,
+				`DROP TABLE IF EXISTS temp."ids"`,
+			},
+		},
+	} {
+		sess, fake := newFakeSession(test.dialect)
+		tx, err := sess.Begin()
+		assert.NoError(t, err)
+		_, err = tx.CreateTempTable("ids", "id bigint", "name text")
+		assert.NoError(t, err)
+		tx.RollbackUnlessCommitted()
+		// the table is dropped once
+		assert.Equal(t, ErrTxRolledBack, tx.Commit())
+
+		var query []string
+		for _, stmt := range fake.statements() {
+			query = append(query, stmt.query)
+		}
+		assert.Equal(t, test.query, query)
+	}
+
+	sess, _ := newFakeSession(dialect.Oracle)
+	tx, err := sess.Begin()
+	assert.NoError(t, err)
+	_, err = tx.CreateTempTable("ids", "id number")
+	assert.Equal(t, ErrNotSupported, err)
+	_, err = tx.CreateTempTable("ids")
+	assert.Equal(t, ErrColumnNotSpecified, err)
+	assert.NoError(t, tx.Rollback())
+}
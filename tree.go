@@ -0,0 +1,73 @@
+package dbr
+
+import "fmt"
+
+const (
+	treeName  = "tree"
+	treeDepth = "depth"
+)
+
+// Tree creates a SelectStmt fetching the subtree of root with WITH RECURSIVE
+// from table, an adjacency list where parentID column references the parent row by id column.
+// Rows are selected from "tree" with their depth in "depth" column, 0 for root,
+// and ordered breadth-first by depth and id. maxDepth limits the depth when it is greater than zero.
+func Tree(table, id, parentID string, root interface{}, maxDepth int) SelectStmt {
+	return createTreeStmt(table, id, parentID, root, maxDepth)
+}
+
+func createTreeStmt(table, id, parentID string, root interface{}, maxDepth int) *selectStmt {
+	subtree := BuildFunc(func(d Dialect, buf Buffer) error {
+		anchor := createSelectStmt([]interface{}{
+			d.QuoteIdent(table) + ".*",
+			"0 AS " + d.QuoteIdent(treeDepth),
+		})
+		anchor.Table = d.QuoteIdent(table)
+		anchor.Where(Eq(id, root))
+		err := anchor.Build(d, buf)
+		if err != nil {
+			return err
+		}
+
+		buf.WriteString(" UNION ALL ")
+
+		step := createSelectStmt([]interface{}{
+			d.QuoteIdent(table) + ".*",
+			d.QuoteIdent(treeName+"."+treeDepth) + " + 1",
+		})
+		step.Table = d.QuoteIdent(table)
+		step.Join(treeName, fmt.Sprintf("%s = %s",
+			d.QuoteIdent(table+"."+parentID),
+			d.QuoteIdent(treeName+"."+id)))
+		if maxDepth > 0 {
+			step.Where(Lt(treeName+"."+treeDepth, maxDepth))
+		}
+		return step.Build(d, buf)
+	})
+
+	stmt := createSelectStmt([]interface{}{"*"})
+	stmt.WithRecursive(treeName, subtree)
+	stmt.Table = I(treeName)
+	stmt.OrderBy(I(treeDepth))
+	stmt.OrderBy(I(id))
+	return stmt
+}
+
+// Tree creates a SelectBuilder fetching the subtree of root, see Tree
+func (sess *Session) Tree(table, id, parentID string, root interface{}, maxDepth int) SelectBuilder {
+	return &selectBuilder{
+		runner:        sess,
+		EventReceiver: sess,
+		Dialect:       sess.Dialect,
+		selectStmt:    createTreeStmt(table, id, parentID, root, maxDepth),
+	}
+}
+
+// Tree creates a SelectBuilder fetching the subtree of root, see Tree
+func (tx *Tx) Tree(table, id, parentID string, root interface{}, maxDepth int) SelectBuilder {
+	return &selectBuilder{
+		runner:        tx,
+		EventReceiver: tx,
+		Dialect:       tx.Dialect,
+		selectStmt:    createTreeStmt(table, id, parentID, root, maxDepth),
+	}
+}
@@ -0,0 +1,79 @@
+package dbr
+
+import (
+	"testing"
+
+	"github.com/lianchengwu/dbr/dialect"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTreeStmt(t *testing.T) {
+	buf := NewBuffer()
+	err := Tree("nodes", "id", "parent_id", 1, 2).Build(dialect.MySQL, buf)
+	assert.NoError(t, err)
+	query, err := InterpolateForDialect(buf.String(), buf.Value(), dialect.MySQL)
+	assert.NoError(t, err)
+	assert.Equal(t, "WITH RECURSIVE `tree` AS ("+
+		"SELECT `nodes`.*, 0 AS `depth` FROM `nodes` WHERE (`id` = 1) UNION ALL "+
+		"SELECT `nodes`.*, `tree`.`depth` + 1 FROM `nodes` JOIN `tree` ON `nodes`.`parent_id` = `tree`.`id` WHERE (`tree`.`depth` < 2)"+
+		") SELECT * FROM `tree` ORDER BY `depth`, `id`", query)
+}
+
+func TestTree(t *testing.T) {
+	type node struct {
+		ID       int64
+		ParentID NullInt64
+		Name     string
+		Depth    int
+	}
+	for _, sess := range testSession {
+		if sess.Dialect == dialect.ClickHouse {
+			// clickhouse does not support WITH RECURSIVE
+			continue
+		}
+		for _, v := range []string{
+			`DROP TABLE IF EXISTS dbr_tree`,
+			`CREATE TABLE dbr_tree (id INTEGER PRIMARY KEY, parent_id INTEGER NULL, name varchar(255) NOT NULL)`,
+		} {
+			_, err := sess.Exec(v)
+			assert.NoError(t, err)
+		}
+		//      1
+		//    3   2
+		//  4       5
+		//          6
+		_, err := sess.InsertInto("dbr_tree").Columns("id", "parent_id", "name").
+			Values(1, nil, "root").
+			Values(2, 1, "b").
+			Values(3, 1, "a").
+			Values(4, 3, "a1").
+			Values(5, 2, "b1").
+			Values(6, 5, "b1a").
+			Values(7, nil, "other").
+			Exec()
+		assert.NoError(t, err)
+
+		var nodes []node
+		_, err = sess.Tree("dbr_tree", "id", "parent_id", 1, 0).Load(&nodes)
+		assert.NoError(t, err)
+		var names []string
+		var depths []int
+		for _, n := range nodes {
+			names = append(names, n.Name)
+			depths = append(depths, n.Depth)
+		}
+		assert.Equal(t, []string{"root", "b", "a", "a1", "b1", "b1a"}, names)
+		assert.Equal(t, []int{0, 1, 1, 2, 2, 3}, depths)
+
+		nodes = nil
+		_, err = sess.Tree("dbr_tree", "id", "parent_id", 2, 1).Load(&nodes)
+		assert.NoError(t, err)
+		if assert.Len(t, nodes, 2) {
+			assert.Equal(t, "b", nodes[0].Name)
+			assert.Equal(t, "b1", nodes[1].Name)
+		}
+
+		_, err = sess.Exec(`DROP TABLE dbr_tree`)
+		assert.NoError(t, err)
+	}
+}
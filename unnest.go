@@ -0,0 +1,73 @@
+package dbr
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Unnest is a table source of the elements of slice value with `unnest(?::typ[]) AS alias(column)`,
+// e.g. Join(Unnest(ids, "int", "x", "id"), "x.id = t.id"). value is bound as a single array
+// parameter instead of a list of values, typ is the SQL type of the elements.
+// It is supported by PostgreSQL, other dialects return ErrNotSupported.
+func Unnest(value interface{}, typ, alias, column string) Builder {
+	return BuildFunc(func(d Dialect, buf Buffer) error {
+		if u, ok := baseDialect(d).(UnnestDialect); !ok || !u.SupportsUnnest() {
+			return ErrNotSupported
+		}
+		buf.WriteString("unnest(")
+		buf.WriteString(placeholder)
+		buf.WriteString("::")
+		buf.WriteString(typ)
+		buf.WriteString("[]) AS ")
+		buf.WriteString(d.QuoteIdent(alias))
+		buf.WriteString("(")
+		buf.WriteString(d.QuoteIdent(column))
+		buf.WriteString(")")
+		return buf.WriteValue(pgArray{value})
+	})
+}
+
+// pgArray is a driver.Valuer encoding a slice as PostgreSQL array literal, e.g. {1,2,3}
+type pgArray struct {
+	value interface{}
+}
+
+func (a pgArray) Value() (driver.Value, error) {
+	v := reflect.ValueOf(a.value)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return nil, fmt.Errorf("dbr: unnest expects a slice, got %T", a.value)
+	}
+	buf := new(bytes.Buffer)
+	buf.WriteString("{")
+	for i := 0; i < v.Len(); i++ {
+		if i > 0 {
+			buf.WriteString(",")
+		}
+		elem, kind := extractOriginal(v.Index(i))
+		switch kind {
+		case reflect.Ptr, reflect.Interface:
+			// nil
+			buf.WriteString("NULL")
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			buf.WriteString(strconv.FormatInt(elem.Int(), 10))
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			buf.WriteString(strconv.FormatUint(elem.Uint(), 10))
+		case reflect.Float32, reflect.Float64:
+			buf.WriteString(strconv.FormatFloat(elem.Float(), 'f', -1, 64))
+		case reflect.Bool:
+			buf.WriteString(strconv.FormatBool(elem.Bool()))
+		case reflect.String:
+			s := strings.Replace(elem.String(), `\`, `\\`, -1)
+			s = strings.Replace(s, `"`, `\"`, -1)
+			buf.WriteString(`"` + s + `"`)
+		default:
+			return nil, fmt.Errorf("dbr: unnest does not support elements of %s", elem.Type())
+		}
+	}
+	buf.WriteString("}")
+	return buf.String(), nil
+}
@@ -0,0 +1,46 @@
+package dbr
+
+import (
+	"database/sql/driver"
+	"testing"
+
+	"github.com/lianchengwu/dbr/dialect"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnnest(t *testing.T) {
+	stmt := Select("t.name").From("t").Join(Unnest([]int64{1, 2, 3}, "int", "x", "id"), "x.id = t.id")
+
+	buf := NewBuffer()
+	err := stmt.Build(dialect.PostgreSQL, buf)
+	assert.NoError(t, err)
+	query, err := InterpolateForDialect(buf.String(), buf.Value(), dialect.PostgreSQL)
+	assert.NoError(t, err)
+	assert.Equal(t, `SELECT t.name FROM t JOIN unnest('{1,2,3}'::int[]) AS "x"("id") ON x.id = t.id`, query)
+
+	// the slice is bound as a single array parameter
+	sess, fake := newFakeSession(dialect.PostgreSQL)
+	sess.DisableInterpolation = true
+	_, err = sess.Select("val").From(Unnest([]string{"a", `b"c`, `d\e`}, "text", "x", "val")).Where(Neq("val", "a")).ReturnStrings()
+	assert.NoError(t, err)
+	stmts := fake.statements()
+	if assert.Len(t, stmts, 1) {
+		assert.Equal(t, `SELECT val FROM unnest($1::text[]) AS "x"("val") WHERE ("val" != $2)`, stmts[0].query)
+		// the fake driver accepts any value, database/sql calls Value for other drivers
+		if assert.Len(t, stmts[0].args, 2) && assert.Implements(t, (*driver.Valuer)(nil), stmts[0].args[0]) {
+			v, err := stmts[0].args[0].(driver.Valuer).Value()
+			assert.NoError(t, err)
+			assert.Equal(t, `{"a","b\"c","d\\e"}`, v)
+		}
+	}
+
+	one := 1
+	v, err := pgArray{[]*int{&one, nil}}.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "{1,NULL}", v)
+	_, err = pgArray{[]interface{}{[]int{1}}}.Value()
+	assert.Error(t, err)
+
+	err = Unnest([]int{1}, "int", "x", "id").Build(dialect.MySQL, NewBuffer())
+	assert.Equal(t, ErrNotSupported, err)
+}
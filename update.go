@@ -1,23 +1,42 @@
 package dbr
 
-import "reflect"
+import (
+	"reflect"
+	"sort"
+	"strings"
+)
 
 // UpdateStmt builds `UPDATE ...`
 type UpdateStmt interface {
 	Builder
 
 	Where(query interface{}, value ...interface{}) UpdateStmt
+	AllRows() UpdateStmt
+	From(table interface{}) UpdateStmt
+	Join(table, on interface{}) UpdateStmt
 	Set(column string, value interface{}) UpdateStmt
 	SetMap(m map[string]interface{}) UpdateStmt
 	SetRecord(structValue interface{}) UpdateStmt
+	Shard(key interface{}) UpdateStmt
 }
 
 type updateStmt struct {
 	raw
 
 	Table     string
+	Column    []string
 	Value     map[string]interface{}
+	FromTable []interface{}
+	JoinTable []updateJoin
 	WhereCond []Builder
+	IsAllRows bool
+	ShardKey  interface{}
+	IsSharded bool
+}
+
+type updateJoin struct {
+	Table interface{}
+	On    interface{}
 }
 
 // Build builds `UPDATE ...` in dialect
@@ -34,26 +53,92 @@ func (b *updateStmt) Build(d Dialect, buf Buffer) error {
 		return ErrColumnNotSpecified
 	}
 
+	joined := len(b.FromTable) > 0 || len(b.JoinTable) > 0
+	m, ok := baseDialect(d).(MultiTableUpdateDialect)
+	multiTable := joined && ok && m.SupportsMultiTableUpdate()
+	if f, ok := baseDialect(d).(UpdateFromDialect); joined && !multiTable && (!ok || !f.SupportsUpdateFrom()) {
+		return ErrNotSupported
+	}
+
+	table := b.Table
+	if b.IsSharded {
+		var err error
+		table, err = resolveTable(d, b.Table, b.ShardKey)
+		if err != nil {
+			return err
+		}
+	}
+
 	buf.WriteString("UPDATE ")
-	buf.WriteString(d.QuoteIdent(b.Table))
+	if joined {
+		// columns and join conditions are qualified with the logical table
+		writeShardTable(d, buf, table, b.Table)
+	} else {
+		buf.WriteString(d.QuoteIdent(table))
+	}
+	if multiTable {
+		for _, table := range b.FromTable {
+			buf.WriteString(", ")
+			writeTable(d, buf, table)
+		}
+		for _, j := range b.JoinTable {
+			err := join(inner, j.Table, j.On).Build(d, buf)
+			if err != nil {
+				return err
+			}
+		}
+	}
 	buf.WriteString(" SET ")
 
-	i := 0
-	for col, v := range b.Value {
+	for i, col := range b.Column {
 		if i > 0 {
 			buf.WriteString(", ")
 		}
-		buf.WriteString(d.QuoteIdent(col))
+		if multiTable && !strings.Contains(col, ".") {
+			// unqualified columns may be ambiguous in joined tables
+			buf.WriteString(d.QuoteIdent(b.Table + "." + col))
+		} else {
+			buf.WriteString(d.QuoteIdent(col))
+		}
 		buf.WriteString(" = ")
-		buf.WriteString(placeholder)
+		err := buildValue(d, buf, b.Value[col])
+		if err != nil {
+			return err
+		}
+	}
 
-		buf.WriteValue(v)
-		i++
+	whereCond := b.WhereCond
+	if joined && !multiTable {
+		// FROM cannot join the updated table, so join conditions are moved to WHERE
+		buf.WriteString(" FROM ")
+		whereCond = nil
+		i := 0
+		for _, table := range b.FromTable {
+			if i > 0 {
+				buf.WriteString(", ")
+			}
+			writeTable(d, buf, table)
+			i++
+		}
+		for _, j := range b.JoinTable {
+			if i > 0 {
+				buf.WriteString(", ")
+			}
+			writeTable(d, buf, j.Table)
+			i++
+			switch on := j.On.(type) {
+			case string:
+				whereCond = append(whereCond, Expr(on))
+			case Builder:
+				whereCond = append(whereCond, on)
+			}
+		}
+		whereCond = append(whereCond, b.WhereCond...)
 	}
 
-	if len(b.WhereCond) > 0 {
+	if len(whereCond) > 0 {
 		buf.WriteString(" WHERE ")
-		err := And(b.WhereCond...).Build(d, buf)
+		err := And(whereCond...).Build(d, buf)
 		if err != nil {
 			return err
 		}
@@ -61,6 +146,16 @@ func (b *updateStmt) Build(d Dialect, buf Buffer) error {
 	return nil
 }
 
+func writeTable(d Dialect, buf Buffer, table interface{}) {
+	switch table := table.(type) {
+	case string:
+		buf.WriteString(d.QuoteIdent(table))
+	default:
+		buf.WriteString(placeholder)
+		buf.WriteValue(table)
+	}
+}
+
 // Update creates an UpdateStmt
 func Update(table string) UpdateStmt {
 	return createUpdateStmt(table)
@@ -99,16 +194,41 @@ func (b *updateStmt) Where(query interface{}, value ...interface{}) UpdateStmt {
 	return b
 }
 
-// Set specifies a key-value pair
+// From adds a table to update rows joined with, e.g. `UPDATE ... FROM table` in PostgreSQL
+// or `UPDATE ..., table` in MySQL; join conditions go to WHERE.
+// Building returns ErrNotSupported in other dialects, e.g. SQLite before 3.33.
+// Values can reference its columns with I, e.g. Set("name", I("other.name")).
+func (b *updateStmt) From(table interface{}) UpdateStmt {
+	b.FromTable = append(b.FromTable, table)
+	return b
+}
+
+// Join joins table on condition, e.g. `UPDATE ... JOIN table ON ...` in MySQL.
+// Dialects with `UPDATE ... FROM` add table to FROM and the condition to WHERE.
+func (b *updateStmt) Join(table, on interface{}) UpdateStmt {
+	b.JoinTable = append(b.JoinTable, updateJoin{Table: table, On: on})
+	return b
+}
+
+// Set specifies a key-value pair, columns are set in order of calls.
+// A Builder value (e.g. Expr("counter + ?", 1)) is rendered in place of the placeholder.
 func (b *updateStmt) Set(column string, value interface{}) UpdateStmt {
+	if _, ok := b.Value[column]; !ok {
+		b.Column = append(b.Column, column)
+	}
 	b.Value[column] = value
 	return b
 }
 
-// SetMap specifies a list of key-value pair
+// SetMap specifies a list of key-value pair, columns are set in sorted order
 func (b *updateStmt) SetMap(m map[string]interface{}) UpdateStmt {
-	for col, val := range m {
-		b.Set(col, val)
+	column := make([]string, 0, len(m))
+	for col := range m {
+		column = append(column, col)
+	}
+	sort.Strings(column)
+	for _, col := range column {
+		b.Set(col, m[col])
 	}
 	return b
 }
@@ -118,12 +238,40 @@ func (b *updateStmt) SetRecord(structValue interface{}) UpdateStmt {
 	v := reflect.Indirect(reflect.ValueOf(structValue))
 
 	if v.Kind() == reflect.Struct {
-		sm := structMap(v.Type())
+		sm, crypt := structFields(v.Type())
 
-		for col, index := range sm {
-			b.Set(col, v.FieldByIndex(index).Interface())
+		column := make([]string, 0, len(sm))
+		for col := range sm {
+			column = append(column, col)
+		}
+		// ensure that the column ordering is deterministic
+		sort.Strings(column)
+		for _, col := range column {
+			if isEmbeddedStruct(v.Type(), sm[col]) {
+				continue
+			}
+			b.Set(col, recordValue(col, v.FieldByIndex(sm[col]), crypt))
 		}
 	}
 
 	return b
 }
+
+// AllRows allows the stmt without WHERE to update all rows, see Session.RequireWhere
+func (b *updateStmt) AllRows() UpdateStmt {
+	b.IsAllRows = true
+	return b
+}
+
+// Shard makes the table the physical table for the shard key, resolved with Session.TableResolver.
+// With From or Join, the physical table is aliased as the table.
+func (b *updateStmt) Shard(key interface{}) UpdateStmt {
+	b.ShardKey = key
+	b.IsSharded = true
+	return b
+}
+
+// missingWhere reports whether the stmt affects all rows without AllRows
+func (b *updateStmt) missingWhere() bool {
+	return b.raw.Query == "" && !b.IsAllRows && len(b.JoinTable) == 0 && matchAllRows(b.WhereCond)
+}
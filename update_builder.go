@@ -3,6 +3,7 @@ package dbr
 import (
 	"database/sql"
 	"fmt"
+	"reflect"
 )
 
 // UpdateBuilder builds `UPDATE ...`
@@ -12,9 +13,15 @@ type UpdateBuilder interface {
 	Executer
 
 	Where(query interface{}, value ...interface{}) UpdateBuilder
+	AllRows() UpdateBuilder
+	From(table interface{}) UpdateBuilder
+	Join(table, on interface{}) UpdateBuilder
 	Set(column string, value interface{}) UpdateBuilder
 	SetMap(m map[string]interface{}) UpdateBuilder
+	SetRecord(structValue interface{}) UpdateBuilder
+	Shard(key interface{}) UpdateBuilder
 	Limit(n uint64) UpdateBuilder
+	ReturnKeys(column string, dest interface{}) (int, error)
 }
 
 type updateBuilder struct {
@@ -75,6 +82,89 @@ func (b *updateBuilder) Exec() (sql.Result, error) {
 	return exec(b.runner, b.EventReceiver, b, b.Dialect)
 }
 
+// ReturnKeys executes the stmt and loads column of the updated rows into dest, e.g. a pointer to []int64.
+// It uses RETURNING if the dialect supports it.
+// Otherwise the keys are loaded by `SELECT column ... FOR UPDATE` with the same WHERE before the update,
+// which costs an extra round trip to hold the row locks (SQLite locks the database in the transaction instead).
+// Both statements run in tx, or in a new transaction for a Session.
+func (b *updateBuilder) ReturnKeys(column string, dest interface{}) (int, error) {
+	if supportsReturning(b.Dialect) {
+		return query(b.runner, b.EventReceiver, BuildFunc(func(d Dialect, buf Buffer) error {
+			return b.build(d, buf, column)
+		}), b.Dialect, dest)
+	}
+	if b.updateStmt.raw.Query != "" || len(b.updateStmt.FromTable) > 0 || len(b.updateStmt.JoinTable) > 0 {
+		return 0, ErrNotSupported
+	}
+	v := reflect.ValueOf(dest)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Slice {
+		return 0, ErrInvalidPointer
+	}
+
+	var r runner = b.runner
+	log := b.EventReceiver
+	tx := b.runner.getTx()
+	begin := tx == nil
+	if begin {
+		var err error
+		tx, err = b.runner.getSession().Begin()
+		if err != nil {
+			return 0, err
+		}
+		defer tx.RollbackUnlessCommitted()
+		r, log = tx, tx.EventReceiver
+	}
+
+	stmt := createSelectStmt([]interface{}{I(column)})
+	stmt.Table = I(b.updateStmt.Table)
+	if b.updateStmt.IsSharded {
+		stmt.Table = Shard(b.updateStmt.Table, b.updateStmt.ShardKey)
+	}
+	stmt.WhereCond = b.updateStmt.WhereCond
+	stmt.LimitCount = b.LimitCount
+	stmt.IsForUpdate = true
+
+	n := v.Elem().Len()
+	// BuildFunc prevents top level statement from being parenthesized as subquery
+	count, err := query(r, log, BuildFunc(stmt.Build), b.Dialect, dest)
+	if err != nil {
+		return 0, err
+	}
+	if count > 0 {
+		update := *b
+		if b.LimitCount >= 0 {
+			// rows are not ordered, so limited updates are restricted to the selected keys
+			updateStmt := *b.updateStmt
+			updateStmt.WhereCond = append(updateStmt.WhereCond[:len(updateStmt.WhereCond):len(updateStmt.WhereCond)],
+				Eq(column, v.Elem().Slice(n, n+count).Interface()))
+			update.updateStmt = &updateStmt
+		}
+		_, err = exec(r, log, &update, b.Dialect)
+		if err != nil {
+			return 0, err
+		}
+	}
+	if begin {
+		err = tx.Commit()
+		if err != nil {
+			return 0, err
+		}
+	}
+	return count, nil
+}
+
+// From adds a table to update rows joined with, see UpdateStmt.From
+func (b *updateBuilder) From(table interface{}) UpdateBuilder {
+	b.updateStmt.From(table)
+	return b
+}
+
+// Join joins table on condition, see UpdateStmt.Join
+func (b *updateBuilder) Join(table, on interface{}) UpdateBuilder {
+	b.updateStmt.Join(table, on)
+	return b
+}
+
 // Set adds "SET column=value"
 func (b *updateBuilder) Set(column string, value interface{}) UpdateBuilder {
 	b.updateStmt.Set(column, value)
@@ -87,12 +177,30 @@ func (b *updateBuilder) SetMap(m map[string]interface{}) UpdateBuilder {
 	return b
 }
 
+// SetRecord adds "SET column=value" for each field of the struct, see UpdateStmt.SetRecord
+func (b *updateBuilder) SetRecord(structValue interface{}) UpdateBuilder {
+	b.updateStmt.SetRecord(structValue)
+	return b
+}
+
 // Where adds condition to the stmt
 func (b *updateBuilder) Where(query interface{}, value ...interface{}) UpdateBuilder {
 	b.updateStmt.Where(query, value...)
 	return b
 }
 
+// AllRows allows the stmt without WHERE to update all rows, see Session.RequireWhere
+func (b *updateBuilder) AllRows() UpdateBuilder {
+	b.updateStmt.AllRows()
+	return b
+}
+
+// Shard makes the table the physical table for the shard key, see Session.TableResolver
+func (b *updateBuilder) Shard(key interface{}) UpdateBuilder {
+	b.updateStmt.Shard(key)
+	return b
+}
+
 // Limit adds LIMIT
 func (b *updateBuilder) Limit(n uint64) UpdateBuilder {
 	b.LimitCount = int64(n)
@@ -101,10 +209,22 @@ func (b *updateBuilder) Limit(n uint64) UpdateBuilder {
 
 // Build builds `UPDATE ...` in dialect
 func (b *updateBuilder) Build(d Dialect, buf Buffer) error {
-	err := b.updateStmt.Build(b.Dialect, buf)
+	return b.build(d, buf, "")
+}
+
+// build writes the stmt with `RETURNING returning` before LIMIT if returning is not empty
+func (b *updateBuilder) build(d Dialect, buf Buffer, returning string) error {
+	if b.runner.getSession().RequireWhere && b.updateStmt.missingWhere() {
+		return ErrMissingWhere
+	}
+	err := b.updateStmt.Build(d, buf)
 	if err != nil {
 		return err
 	}
+	if returning != "" {
+		buf.WriteString(" RETURNING ")
+		buf.WriteString(d.QuoteIdent(returning))
+	}
 	if b.LimitCount >= 0 {
 		buf.WriteString(" LIMIT ")
 		buf.WriteString(fmt.Sprint(b.LimitCount))
@@ -0,0 +1,116 @@
+package dbr
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lianchengwu/dbr/dialect"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateBuilderReturnKeys(t *testing.T) {
+	sess, dbmock := newSessionMock()
+	dbmock.ExpectBegin()
+	dbmock.ExpectQuery(regexp.QuoteMeta("SELECT `id` FROM `users` WHERE (`active` = 1) FOR UPDATE")).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2))
+	dbmock.ExpectExec(regexp.QuoteMeta("UPDATE `users` SET `name` = 'x' WHERE (`active` = 1)")).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	dbmock.ExpectCommit()
+
+	var ids []int64
+	count, err := sess.Update("users").Set("name", "x").Where(Eq("active", true)).ReturnKeys("id", &ids)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.Equal(t, []int64{1, 2}, ids)
+	assert.NoError(t, dbmock.ExpectationsWereMet())
+
+	// limited updates are restricted to the selected keys
+	dbmock.ExpectBegin()
+	tx, err := sess.Begin()
+	assert.NoError(t, err)
+	dbmock.ExpectQuery(regexp.QuoteMeta("SELECT `id` FROM `users` WHERE (`active` = 1) LIMIT 1 FOR UPDATE")).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+	dbmock.ExpectExec(regexp.QuoteMeta("UPDATE `users` SET `name` = 'x' WHERE (`active` = 1) AND (`id` IN (3)) LIMIT 1")).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	count, err = tx.Update("users").Set("name", "x").Where(Eq("active", true)).Limit(1).ReturnKeys("id", &ids)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.Equal(t, []int64{1, 2, 3}, ids)
+	assert.NoError(t, dbmock.ExpectationsWereMet())
+
+	// no update without keys
+	dbmock.ExpectQuery(regexp.QuoteMeta("SELECT `id` FROM `users` WHERE (`active` = 0) FOR UPDATE")).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	count, err = tx.Update("users").Set("name", "x").Where(Eq("active", false)).ReturnKeys("id", &ids)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, count)
+	assert.NoError(t, dbmock.ExpectationsWereMet())
+}
+
+func TestUpdateBuilderReturning(t *testing.T) {
+	sess, fake := newFakeSession(dialect.PostgreSQL)
+	fake.columns = []string{"id"}
+	fake.rows = [][]driver.Value{{int64(1)}}
+
+	var ids []int64
+	count, err := sess.Update("users").Set("name", "x").Where(Eq("active", true)).ReturnKeys("id", &ids)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.Equal(t, []int64{1}, ids)
+
+	stmts := fake.statements()
+	assert.Len(t, stmts, 1)
+	assert.Equal(t, `UPDATE "users" SET "name" = 'x' WHERE ("active" = TRUE) RETURNING "id"`, stmts[0].query)
+}
+
+func TestUpdateBuilderReturnKeysFallback(t *testing.T) {
+	for _, sess := range testSession {
+		if supportsReturning(sess.Dialect) || sess.Dialect == dialect.ClickHouse {
+			continue
+		}
+		prefix := fmt.Sprintf("return_keys_%d_", nextID())
+		_, err := sess.InsertInto("dbr_keys").Columns("key_value", "val_value").
+			Values(prefix+"1", "a").Values(prefix+"2", "a").Values(prefix+"3", "b").Exec()
+		assert.NoError(t, err)
+
+		var keys []string
+		count, err := sess.Update("dbr_keys").Set("val_value", "c").
+			Where(Like("key_value", prefix+"%")).Where(Eq("val_value", "a")).ReturnKeys("key_value", &keys)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, count)
+		assert.ElementsMatch(t, []string{prefix + "1", prefix + "2"}, keys)
+
+		var values []string
+		_, err = sess.Select("val_value").From("dbr_keys").Where(Like("key_value", prefix+"%")).
+			OrderBy("key_value").Load(&values)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"c", "c", "b"}, values)
+
+		// the queries run in the transaction of a wrapped runner
+		tx, err := sess.Begin()
+		assert.NoError(t, err)
+		update := tx.Update("dbr_keys").Set("val_value", "d").Where(Eq("key_value", prefix+"3")).(*updateBuilder)
+		update.runner = contextRunner{runner: tx, ctx: context.Background()}
+		keys = nil
+		count, err = update.ReturnKeys("key_value", &keys)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, count)
+		assert.NoError(t, tx.Rollback())
+		var value string
+		err = sess.Select("val_value").From("dbr_keys").Where(Eq("key_value", prefix+"3")).LoadValue(&value)
+		assert.NoError(t, err)
+		assert.Equal(t, "b", value)
+	}
+}
+
+func TestUpdateBuilderReturningLimit(t *testing.T) {
+	buf := NewBuffer()
+	sess, _ := newFakeSession(dialect.SQLite3)
+	update := sess.Update("users").Set("name", "x").Limit(1).(*updateBuilder)
+	assert.NoError(t, update.build(dialect.SQLite3, buf, "id"))
+	assert.Equal(t, `UPDATE "users" SET "name" = ? RETURNING "id" LIMIT 1`, buf.String())
+}
@@ -26,6 +26,119 @@ func TestUpdateStmtSetRecord(t *testing.T) {
 
 	assert.Equal(t, "UPDATE `table` SET `a` = ? WHERE (`b` = ?)", buf.String())
 	assert.Equal(t, []interface{}{1, 2}, buf.Value())
+
+	// fields of unexported embedded structs are columns
+	embedded := struct {
+		insertTenant
+		A int
+	}{insertTenant{TenantID: 3}, 1}
+	buf = NewBuffer()
+	err = Update("table").SetRecord(&embedded).Build(dialect.MySQL, buf)
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE `table` SET `a` = ?, `tenant_id` = ?", buf.String())
+	assert.Equal(t, []interface{}{1, int64(3)}, buf.Value())
+}
+
+func TestUpdateStmtSetExpr(t *testing.T) {
+	buf := NewBuffer()
+	builder := Update("table").
+		Set("name", "one").
+		SetMap(map[string]interface{}{
+			"updated_at": Expr("NOW()"),
+			"counter":    Expr("counter + ?", 1),
+			"b":          2,
+		}).
+		Set("name", "two").
+		Where(Eq("id", 3))
+	err := builder.Build(dialect.MySQL, buf)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "UPDATE `table` SET `name` = ?, `b` = ?, `counter` = counter + ?, `updated_at` = NOW() WHERE (`id` = ?)", buf.String())
+	assert.Equal(t, []interface{}{"two", 2, 1, 3}, buf.Value())
+}
+
+func TestUpdateStmtJoin(t *testing.T) {
+	for _, test := range []struct {
+		dialect Dialect
+		builder UpdateStmt
+		query   string
+		value   []interface{}
+	}{
+		{
+			dialect: dialect.PostgreSQL,
+			builder: Update("orders").
+				From("customers").
+				Set("region", I("customers.region")).
+				Set("note", "x").
+				Where("orders.customer_id = customers.id").
+				Where(Eq("customers.active", true)),
+			query: `UPDATE "orders" SET "region" = "customers"."region", "note" = ? FROM "customers" ` +
+				`WHERE (orders.customer_id = customers.id) AND ("customers"."active" = ?)`,
+			value: []interface{}{"x", true},
+		},
+		{
+			dialect: dialect.PostgreSQL,
+			builder: Update("orders").
+				Join("customers", Expr("orders.customer_id = customers.id AND customers.kind = ?", 1)).
+				Set("region", I("customers.region")).
+				Where(Gt("orders.id", 2)),
+			query: `UPDATE "orders" SET "region" = "customers"."region" FROM "customers" ` +
+				`WHERE (orders.customer_id = customers.id AND customers.kind = ?) AND ("orders"."id" > ?)`,
+			value: []interface{}{1, 2},
+		},
+		{
+			dialect: dialect.MySQL,
+			builder: Update("orders").
+				Join("customers", "orders.customer_id = customers.id").
+				Set("region", I("customers.region")).
+				Set("customers.seen", 3).
+				Where(Gt("orders.id", 2)),
+			query: "UPDATE `orders` JOIN `customers` ON orders.customer_id = customers.id " +
+				"SET `orders`.`region` = `customers`.`region`, `customers`.`seen` = ? WHERE (`orders`.`id` > ?)",
+			value: []interface{}{3, 2},
+		},
+		{
+			dialect: dialect.MySQL,
+			builder: Update("orders").
+				From("customers").
+				Set("region", I("customers.region")).
+				Where("orders.customer_id = customers.id"),
+			query: "UPDATE `orders`, `customers` SET `orders`.`region` = `customers`.`region` WHERE (orders.customer_id = customers.id)",
+		},
+		{
+			dialect: dialect.SQLite3Version(3, 33),
+			builder: Update("orders").
+				From("customers").
+				Set("region", I("customers.region")).
+				Where("orders.customer_id = customers.id"),
+			query: `UPDATE "orders" SET "region" = "customers"."region" FROM "customers" WHERE (orders.customer_id = customers.id)`,
+		},
+	} {
+		buf := NewBuffer()
+		err := test.builder.Build(test.dialect, buf)
+		assert.NoError(t, err)
+		assert.Equal(t, test.query, buf.String())
+		assert.Equal(t, test.value, buf.Value())
+	}
+
+	for _, d := range []Dialect{dialect.Oracle, dialect.SQLite3} {
+		err := Update("orders").From("customers").Set("a", 1).Build(d, NewBuffer())
+		assert.Equal(t, ErrNotSupported, err)
+	}
+
+	// values are interpolated in order of SET and WHERE
+	sess, fake := newFakeSession(dialect.MySQL)
+	_, err := sess.Update("orders").
+		Join("customers", Expr("orders.customer_id = customers.id AND customers.kind = ?", "vip")).
+		Set("discount", 10).
+		Where(Eq("orders.status", "new")).
+		Exec()
+	assert.NoError(t, err)
+	stmts := fake.statements()
+	if assert.Len(t, stmts, 1) {
+		assert.Equal(t, "UPDATE `orders` JOIN `customers` ON orders.customer_id = customers.id AND customers.kind = 'vip' "+
+			"SET `orders`.`discount` = 10 WHERE (`orders`.`status` = 'new')", stmts[0].query)
+	}
 }
 
 func BenchmarkUpdateValuesSQL(b *testing.B) {
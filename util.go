@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"database/sql/driver"
 	"reflect"
+	"strings"
 	"unicode"
 )
 
@@ -26,22 +27,29 @@ func camelCaseToSnakeCase(name string) string {
 
 // structMap builds index to fast lookup fields in struct
 func structMap(t reflect.Type) map[string][]int {
-	m := make(map[string][]int)
-	structTraverse(m, t, nil)
+	m, _ := structFields(t)
 	return m
 }
 
+// structFields is like structMap, and it also returns the columns of fields tagged with crypt
+func structFields(t reflect.Type) (map[string][]int, map[string]bool) {
+	m := make(map[string][]int)
+	crypt := make(map[string]bool)
+	structTraverse(m, crypt, t, nil)
+	return m, crypt
+}
+
 var (
 	typeValuer = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
 )
 
-func structTraverse(m map[string][]int, t reflect.Type, head []int) {
+func structTraverse(m map[string][]int, crypt map[string]bool, t reflect.Type, head []int) {
 	if t.Implements(typeValuer) {
 		return
 	}
 	switch t.Kind() {
 	case reflect.Ptr:
-		structTraverse(m, t.Elem(), head)
+		structTraverse(m, crypt, t.Elem(), head)
 	case reflect.Struct:
 		for i := 0; i < t.NumField(); i++ {
 			field := t.Field(i)
@@ -49,7 +57,7 @@ func structTraverse(m map[string][]int, t reflect.Type, head []int) {
 				// unexported
 				continue
 			}
-			tag := field.Tag.Get("db")
+			tag, opts := parseTag(field.Tag.Get("db"))
 			if tag == "-" {
 				// ignore
 				continue
@@ -60,10 +68,50 @@ func structTraverse(m map[string][]int, t reflect.Type, head []int) {
 			}
 			if _, ok := m[tag]; !ok {
 				m[tag] = append(head, i)
+				if hasOption(opts, "crypt") {
+					crypt[tag] = true
+				}
 			}
-			structTraverse(m, field.Type, append(head, i))
+			structTraverse(m, crypt, field.Type, append(head, i))
+		}
+	}
+}
+
+// isEmbeddedStruct reports whether the field of t at index is an embedded struct,
+// whose fields are the columns of structMap rather than the struct itself
+func isEmbeddedStruct(t reflect.Type, index []int) bool {
+	field := t.FieldByIndex(index)
+	ft := field.Type
+	if ft.Kind() == reflect.Ptr {
+		ft = ft.Elem()
+	}
+	return field.Anonymous && ft.Kind() == reflect.Struct && ft != typeTime &&
+		!field.Type.Implements(typeValuer)
+}
+
+// isPrimaryKey reports whether the field of t at index is tagged with pk, e.g. `db:"id,pk"`
+func isPrimaryKey(t reflect.Type, index []int) bool {
+	_, opts := parseTag(t.FieldByIndex(index).Tag.Get("db"))
+	return hasOption(opts, "pk")
+}
+
+// parseTag splits a db tag into the column and its comma separated options, e.g. "ssn,crypt"
+func parseTag(tag string) (string, string) {
+	if i := strings.IndexByte(tag, ','); i >= 0 {
+		return tag[:i], tag[i+1:]
+	}
+	return tag, ""
+}
+
+func hasOption(opts, name string) bool {
+	for opts != "" {
+		var opt string
+		opt, opts = parseTag(opts)
+		if opt == name {
+			return true
 		}
 	}
+	return false
 }
 
 // extractOriginal removes all ptr and interface wrappers